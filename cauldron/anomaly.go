@@ -0,0 +1,394 @@
+package cauldron
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+const (
+	// Low is the severity of an anomaly barely over the threshold of its detector
+	Low = "low"
+	// Medium is the severity of an anomaly over 1.5 times the threshold of its detector
+	Medium = "medium"
+	// High is the severity of an anomaly over twice the threshold of its detector
+	High = "high"
+)
+
+// minBaseline is the number of previous values needed to score a point with
+// a statistical detector.
+const minBaseline = 3
+
+// Deviation is a point of a series flagged by a detector.
+type Deviation struct {
+	Index    int
+	Expected float64
+	Score    float64
+	Severity string
+}
+
+// Detector flags the points of a series deviating from the previous ones.
+// Missing values are skipped.
+type Detector interface {
+	Name() string
+	Detect(values []*float64) []Deviation
+}
+
+// ZScore flags the points whose z-score over the previous values in a rolling
+// window reaches the threshold.
+type ZScore struct {
+	Window    int
+	Threshold float64
+}
+
+// Name returns the name of the detector.
+func (d ZScore) Name() string {
+	return "zscore"
+}
+
+// Detect returns the points deviating from the mean of the window.
+func (d ZScore) Detect(values []*float64) []Deviation {
+	deviations := []Deviation{}
+
+	for i, window := range windows(values, d.Window) {
+		if len(window) < minBaseline {
+			continue
+		}
+
+		mean := 0.0
+		for _, v := range window {
+			mean += v
+		}
+		mean /= float64(len(window))
+
+		variance := 0.0
+		for _, v := range window {
+			variance += (v - mean) * (v - mean)
+		}
+		sd := math.Sqrt(variance / float64(len(window)-1))
+
+		// constant windows have no spread to compare with
+		if sd == 0 {
+			continue
+		}
+
+		score := (*values[i] - mean) / sd
+		if severity := severity(score, d.Threshold); severity != "" {
+			deviations = append(deviations, Deviation{Index: i, Expected: mean, Score: score, Severity: severity})
+		}
+	}
+
+	return deviations
+}
+
+// MAD flags the points whose modified z-score, based on the median absolute
+// deviation of the previous values in a rolling window, reaches the
+// threshold. It's less sensitive to previous outliers than the z-score.
+type MAD struct {
+	Window    int
+	Threshold float64
+}
+
+// Name returns the name of the detector.
+func (d MAD) Name() string {
+	return "mad"
+}
+
+// Detect returns the points deviating from the median of the window.
+func (d MAD) Detect(values []*float64) []Deviation {
+	deviations := []Deviation{}
+
+	for i, window := range windows(values, d.Window) {
+		if len(window) < minBaseline {
+			continue
+		}
+
+		med := median(window)
+
+		absolute := make([]float64, len(window))
+		for j, v := range window {
+			absolute[j] = math.Abs(v - med)
+		}
+
+		mad := median(absolute)
+		if mad == 0 {
+			continue
+		}
+
+		// 0.6745 makes the score comparable to a z-score for normal data
+		score := 0.6745 * (*values[i] - med) / mad
+		if severity := severity(score, d.Threshold); severity != "" {
+			deviations = append(deviations, Deviation{Index: i, Expected: med, Score: score, Severity: severity})
+		}
+	}
+
+	return deviations
+}
+
+// PercentChange flags the points changing from the previous value by a
+// percentage reaching the threshold.
+type PercentChange struct {
+	Threshold float64
+}
+
+// Name returns the name of the detector.
+func (d PercentChange) Name() string {
+	return "pct-change"
+}
+
+// Detect returns the points changing abruptly from the previous value.
+func (d PercentChange) Detect(values []*float64) []Deviation {
+	deviations := []Deviation{}
+
+	for i, window := range windows(values, 1) {
+		// the change from zero is undefined
+		if len(window) == 0 || window[0] == 0 {
+			continue
+		}
+
+		previous := window[0]
+		score := (*values[i] - previous) / math.Abs(previous) * 100
+		if severity := severity(score, d.Threshold); severity != "" {
+			deviations = append(deviations, Deviation{Index: i, Expected: previous, Score: score, Severity: severity})
+		}
+	}
+
+	return deviations
+}
+
+// ParseDetectors returns the detectors with the given names, sharing the
+// window and using their own thresholds.
+func ParseDetectors(names []string, window int, zscore float64, pctChange float64, mad float64) ([]Detector, error) {
+	if window < minBaseline {
+		return nil, fmt.Errorf("invalid window %d: it must be at least %d", window, minBaseline)
+	}
+
+	detectors := []Detector{}
+	for _, name := range names {
+		switch name {
+		case "zscore":
+			detectors = append(detectors, ZScore{Window: window, Threshold: zscore})
+		case "pct-change":
+			detectors = append(detectors, PercentChange{Threshold: pctChange})
+		case "mad":
+			detectors = append(detectors, MAD{Window: window, Threshold: mad})
+		default:
+			return nil, fmt.Errorf("unknown detector %q: possible values are zscore, pct-change and mad", name)
+		}
+	}
+
+	return detectors, nil
+}
+
+// windows returns, for each point, up to size previous values, skipping the
+// missing ones. The windows of the missing points are empty.
+func windows(values []*float64, size int) [][]float64 {
+	result := make([][]float64, len(values))
+	previous := []float64{}
+
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+
+		start := len(previous) - size
+		if start < 0 {
+			start = 0
+		}
+
+		result[i] = append([]float64{}, previous[start:]...)
+		previous = append(previous, *v)
+	}
+
+	return result
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// severity returns the severity of a score by how much it reaches the
+// threshold, or empty if it doesn't.
+func severity(score float64, threshold float64) string {
+	ratio := math.Abs(score) / threshold
+
+	switch {
+	case ratio >= 2:
+		return High
+	case ratio >= 1.5:
+		return Medium
+	case ratio >= 1:
+		return Low
+	default:
+		return ""
+	}
+}
+
+// severities sorts the severities from the most to the least severe.
+var severities = map[string]int{High: 0, Medium: 1, Low: 2}
+
+// AtLeast returns true if the severity is the given one or a more severe one.
+func AtLeast(severity string, min string) bool {
+	return severities[severity] <= severities[min]
+}
+
+// ParseSeverity validates a severity.
+func ParseSeverity(s string) (string, error) {
+	if _, ok := severities[s]; !ok {
+		return "", fmt.Errorf("unknown severity %q: possible values are low, medium and high", s)
+	}
+
+	return s, nil
+}
+
+// Anomaly is a point of the history of a metric flagged by a detector.
+type Anomaly struct {
+	ProjectID   int     `json:"project_id"`
+	ProjectName string  `json:"project_name"`
+	Tab         string  `json:"tab"`
+	Key         string  `json:"key"`
+	Name        string  `json:"name"`
+	FetchedAt   string  `json:"fetched_at,omitempty"`
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	Value       float64 `json:"value"`
+	// Expected is the baseline of the detector: the mean or median of the
+	// window, or the previous value.
+	Expected float64 `json:"expected"`
+	Detector string  `json:"detector"`
+	Score    float64 `json:"score"`
+	Severity string  `json:"severity"`
+	// Status tells if the deviation is better or worse for the metric.
+	Status string `json:"status"`
+
+	precision int
+}
+
+// AnomalyResponse is the JSON document of the anomalies.
+type AnomalyResponse struct {
+	SchemaVersion int       `json:"schema_version"`
+	Anomalies     []Anomaly `json:"anomalies"`
+}
+
+// TrendsFromSeries returns the trends of the time series of a tab, fetched
+// for consecutive windows instead of stored.
+func TrendsFromSeries(p project.Project, tab string, series []Series) []Trend {
+	trends := make([]Trend, len(series))
+	for i, s := range series {
+		points := make([]TrendPoint, len(s.Points))
+		for j, point := range s.Points {
+			points[j] = TrendPoint{From: point.From, To: point.To, Value: point.Value}
+		}
+
+		trends[i] = Trend{ProjectID: p.ID, ProjectName: p.Name, Tab: tab, Key: s.Key, Name: s.Name, Points: points, precision: s.precision}
+		if len(points) > 0 {
+			trends[i].Days = spanDays(points[0].From, points[0].To)
+		}
+		trends[i].summarize()
+	}
+
+	return trends
+}
+
+// DetectAnomalies applies the detectors to the trends, returning the flagged
+// points of at least the given severity, from the most to the least severe.
+func DetectAnomalies(trends []Trend, detectors []Detector, min string) []Anomaly {
+	anomalies := []Anomaly{}
+
+	for _, t := range trends {
+		values := make([]*float64, len(t.Points))
+		for i, p := range t.Points {
+			values[i] = p.Value
+		}
+
+		for _, d := range detectors {
+			for _, deviation := range d.Detect(values) {
+				if !AtLeast(deviation.Severity, min) {
+					continue
+				}
+
+				point := t.Points[deviation.Index]
+				status := Worse
+				if (deviation.Score < 0) == LowerIsBetter(t.Key) {
+					status = Better
+				}
+
+				anomalies = append(anomalies, Anomaly{
+					ProjectID:   t.ProjectID,
+					ProjectName: t.ProjectName,
+					Tab:         t.Tab,
+					Key:         t.Key,
+					Name:        t.Name,
+					FetchedAt:   point.FetchedAt,
+					From:        point.From,
+					To:          point.To,
+					Value:       *point.Value,
+					Expected:    deviation.Expected,
+					Detector:    d.Name(),
+					Score:       deviation.Score,
+					Severity:    deviation.Severity,
+					Status:      status,
+					precision:   t.precision,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		return severities[anomalies[i].Severity] < severities[anomalies[j].Severity]
+	})
+
+	return anomalies
+}
+
+// NewAnomalyReport renders the anomalies as a table, highlighting the ones
+// worse for their metric in the console and markdown formats.
+func NewAnomalyReport(anomalies []Anomaly) Report {
+	table := Table{
+		Title:   "Anomalies",
+		Headers: []string{"Project", "Tab", "Metric", "Point", "Value", "Expected", "Detector", "Score", "Severity", "Status"},
+	}
+
+	records := Table{
+		Headers: []string{"project_id", "project_name", "tab", "metric", "fetched_at", "from", "to", "value", "expected", "detector", "score", "severity", "status"},
+	}
+
+	for _, a := range anomalies {
+		point := a.From + " - " + a.To
+		if a.FetchedAt != "" {
+			point = a.FetchedAt
+		}
+
+		value := Metric{Value: Float(a.Value), Precision: a.precision}.String()
+		expected := Metric{Value: Float(a.Expected), Precision: 2}.String()
+		score := Metric{Value: Float(a.Score), Precision: 2}.String()
+
+		status := a.Status
+		if status == Worse {
+			status = "** " + Worse + " **"
+		}
+
+		table.Rows = append(table.Rows, []string{a.ProjectName, a.Tab, a.Name, point, value, expected, a.Detector, score, a.Severity, status})
+		records.Rows = append(records.Rows, []string{strconv.Itoa(a.ProjectID), a.ProjectName, a.Tab, a.Key, a.FetchedAt, a.From, a.To, value, expected, a.Detector, score, a.Severity, a.Status})
+	}
+
+	return Report{
+		Tables: []Table{table},
+		Document: AnomalyResponse{
+			SchemaVersion: SchemaVersion,
+			Anomalies:     anomalies,
+		},
+		Records: &records,
+	}
+}
@@ -0,0 +1,142 @@
+package cauldron_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+func floats(values ...float64) []*float64 {
+	result := make([]*float64, len(values))
+	for i, v := range values {
+		result[i] = cauldron.Float(v)
+	}
+
+	return result
+}
+
+func TestDetectors(t *testing.T) {
+	stable := floats(10, 11, 9, 10, 11, 9, 10)
+
+	testCases := []struct {
+		name     string
+		detector cauldron.Detector
+		values   []*float64
+		expected []cauldron.Deviation
+	}{
+		{
+			name:     "zscore/stable",
+			detector: cauldron.ZScore{Window: 8, Threshold: 3},
+			values:   stable,
+			expected: []cauldron.Deviation{},
+		},
+		{
+			name:     "zscore/collapse",
+			detector: cauldron.ZScore{Window: 8, Threshold: 3},
+			values:   append(stable, cauldron.Float(0)),
+			expected: []cauldron.Deviation{{Index: 7, Expected: 10, Score: -12.247448713915889, Severity: cauldron.High}},
+		},
+		{
+			name:     "zscore/short-baseline",
+			detector: cauldron.ZScore{Window: 8, Threshold: 3},
+			values:   floats(10, 11, 100),
+			expected: []cauldron.Deviation{},
+		},
+		{
+			name:     "mad/spike",
+			detector: cauldron.MAD{Window: 4, Threshold: 3.5},
+			values:   append(floats(10, 12, 10, 12), cauldron.Float(17)),
+			expected: []cauldron.Deviation{{Index: 4, Expected: 11, Score: 4.047, Severity: cauldron.Low}},
+		},
+		{
+			name:     "pct-change/missing",
+			detector: cauldron.PercentChange{Threshold: 50},
+			values:   []*float64{cauldron.Float(10), nil, cauldron.Float(16), cauldron.Float(4)},
+			expected: []cauldron.Deviation{{Index: 2, Expected: 10, Score: 60, Severity: cauldron.Low}, {Index: 3, Expected: 16, Score: -75, Severity: cauldron.Medium}},
+		},
+		{
+			name:     "pct-change/from-zero",
+			detector: cauldron.PercentChange{Threshold: 50},
+			values:   floats(0, 10),
+			expected: []cauldron.Deviation{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			got := testCase.detector.Detect(testCase.values)
+			if len(got) != len(testCase.expected) {
+				tt.Fatalf("expected %v but got %v", testCase.expected, got)
+			}
+
+			for i, d := range got {
+				e := testCase.expected[i]
+				if d.Index != e.Index || d.Expected != e.Expected || d.Severity != e.Severity || d.Score-e.Score > 1e-9 || e.Score-d.Score > 1e-9 {
+					tt.Fatalf("expected %v but got %v", e, d)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDetectors(t *testing.T) {
+	detectors, err := cauldron.ParseDetectors([]string{"zscore", "mad"}, 8, 3, 50, 3.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(detectors) != 2 || detectors[0].Name() != "zscore" || detectors[1].Name() != "mad" {
+		t.Fatalf("unexpected detectors %v", detectors)
+	}
+
+	if _, err := cauldron.ParseDetectors([]string{"prophet"}, 8, 3, 50, 3.5); err == nil {
+		t.Fatal("expected an error for an unknown detector")
+	}
+
+	if _, err := cauldron.ParseDetectors([]string{"zscore"}, 2, 3, 50, 3.5); err == nil {
+		t.Fatal("expected an error for a short window")
+	}
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	p := project.Project{ID: 1, Name: "go"}
+	windows := []period.Period{}
+	printables := []cauldron.Printable{}
+
+	for i, v := range []int{20, 22, 21, 20, 2} {
+		start := date(t, "2024-01-01").AddDate(0, 0, i)
+		windows = append(windows, period.Period{From: start, To: start.AddDate(0, 0, 1)})
+		printables = append(printables, &cauldron.Activity{CommitsActivityOverview: cauldron.Int(v)})
+	}
+
+	trends := cauldron.TrendsFromSeries(p, "activity-overview", cauldron.NewSeries(windows, printables))
+	detectors := []cauldron.Detector{cauldron.PercentChange{Threshold: 50}, cauldron.ZScore{Window: 8, Threshold: 3}}
+
+	anomalies := cauldron.DetectAnomalies(trends, detectors, cauldron.Low)
+	if len(anomalies) != 2 {
+		t.Fatalf("expected 2 anomalies but got %v", anomalies)
+	}
+
+	// the most severe first, and fewer commits are worse
+	if a := anomalies[0]; a.Detector != "zscore" || a.Severity != cauldron.High || a.Status != cauldron.Worse || a.Value != 2 || a.From != "2024-01-05" {
+		t.Fatalf("unexpected anomaly %+v", a)
+	}
+
+	if a := anomalies[1]; a.Detector != "pct-change" || a.Severity != cauldron.Medium {
+		t.Fatalf("unexpected anomaly %+v", a)
+	}
+
+	if got := cauldron.DetectAnomalies(trends, detectors, cauldron.High); len(got) != 1 {
+		t.Fatalf("expected only the high anomaly but got %v", got)
+	}
+
+	report := cauldron.NewAnomalyReport(anomalies)
+	if row := report.Tables[0].Rows[0]; row[3] != "2024-01-05 - 2024-01-06" || row[9] != "** worse **" {
+		t.Fatalf("unexpected row %v", row)
+	}
+}
@@ -5,17 +5,24 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/period"
 )
 
 const (
+	// DefaultBaseURL is the base URL of the public Cauldron instance.
+	DefaultBaseURL = "https://cauldron.io"
+
 	baseScheme               = "https"
 	baseURL                  = "cauldron.io"
 	metricsURLFormat         = "/project/%d/metrics"
 	metricsQueryStringFormat = "from=%s&to=%s&tab=%s"
 )
 
-func NewURL(projectID int, from, to, tab string, repoURLs []string) url.URL {
-	rawQuery := fmt.Sprintf(metricsQueryStringFormat, from, to, tab)
+func NewURL(projectID int, from, to time.Time, tab string, repoURLs []string) url.URL {
+	rawQuery := fmt.Sprintf(metricsQueryStringFormat, from.Format(period.Layout), to.Format(period.Layout), tab)
 	if len(repoURLs) > 0 {
 		queryRepos := ""
 		for _, repoURL := range repoURLs {
@@ -33,7 +40,39 @@ func NewURL(projectID int, from, to, tab string, repoURLs []string) url.URL {
 	}
 }
 
-func HttpRequest(url url.URL) (io.ReadCloser, int, error) {
+// Rebase returns the metrics URL on another Cauldron instance, e.g. a
+// self-hosted one, keeping its path and query.
+func Rebase(u url.URL, base string) (url.URL, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return url.URL{}, fmt.Errorf("error parsing the base URL: %w", err)
+	}
+
+	if b.Scheme == "" || b.Host == "" {
+		return url.URL{}, fmt.Errorf("error parsing the base URL %q: expected scheme://host", base)
+	}
+
+	u.Scheme = b.Scheme
+	u.Host = b.Host
+	u.Path = strings.TrimSuffix(b.Path, "/") + u.Path
+
+	return u, nil
+}
+
+// RequestOption customises the requests to Cauldron.
+type RequestOption func(req *http.Request)
+
+// WithToken authenticates the requests with the API token of a Cauldron
+// user, e.g. to access private projects. An empty token is not sent.
+func WithToken(token string) RequestOption {
+	return func(req *http.Request) {
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+}
+
+func HttpRequest(url url.URL, opts ...RequestOption) (io.ReadCloser, int, error) {
 	httpCli := http.Client{}
 
 	req, err := http.NewRequest("GET", url.String(), nil)
@@ -41,11 +80,15 @@ func HttpRequest(url url.URL) (io.ReadCloser, int, error) {
 		return nil, http.StatusInternalServerError, fmt.Errorf("error creating HTTP request: %v", err)
 	}
 
-	req.Header.Add("Authority", "cauldron.io")
+	req.Header.Add("Authority", url.Host)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", "CauldronGo")
 
+	for _, opt := range opts {
+		opt(req)
+	}
+
 	resp, err := httpCli.Do(req)
 	if err != nil {
 		return nil, http.StatusInternalServerError, fmt.Errorf("error making HTTP request: %v", err)
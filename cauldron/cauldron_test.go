@@ -33,8 +33,8 @@ func TestMockHTTPRequests(t *testing.T) {
 			assertFunc: func(t *testing.T, printable cauldron.Printable) {
 				a := printable.(*cauldron.Activity)
 
-				if a.CommitsActivityOverview != 15 {
-					t.Fatalf("expected CommitsActivityOverview=15 but got %d", a.CommitsActivityOverview)
+				if a.CommitsActivityOverview == nil || *a.CommitsActivityOverview != 15 {
+					t.Fatalf("expected CommitsActivityOverview=15 but got %v", a.CommitsActivityOverview)
 				}
 			},
 		},
@@ -45,8 +45,8 @@ func TestMockHTTPRequests(t *testing.T) {
 			assertFunc: func(t *testing.T, printable cauldron.Printable) {
 				c := printable.(*cauldron.Community)
 
-				if c.ActivePeopleGitCommunityOverview != 8 {
-					t.Fatalf("expected ActivePeopleGitCommunityOverview=8 but got %d", c.ActivePeopleGitCommunityOverview)
+				if c.ActivePeopleGitCommunityOverview == nil || *c.ActivePeopleGitCommunityOverview != 8 {
+					t.Fatalf("expected ActivePeopleGitCommunityOverview=8 but got %v", c.ActivePeopleGitCommunityOverview)
 				}
 			},
 		},
@@ -57,8 +57,8 @@ func TestMockHTTPRequests(t *testing.T) {
 			assertFunc: func(t *testing.T, printable cauldron.Printable) {
 				o := printable.(*cauldron.Overview)
 
-				if o.CommitsOverview != 1581 {
-					t.Fatalf("expected CommitsOverview=1581 but got %d", o.CommitsOverview)
+				if o.CommitsOverview == nil || *o.CommitsOverview != 1581 {
+					t.Fatalf("expected CommitsOverview=1581 but got %v", o.CommitsOverview)
 				}
 			},
 		},
@@ -69,8 +69,8 @@ func TestMockHTTPRequests(t *testing.T) {
 			assertFunc: func(t *testing.T, printable cauldron.Printable) {
 				p := printable.(*cauldron.Performance)
 
-				if p.IssuesTimeOpenAveragePerformanceOverview != 272.41 {
-					t.Fatalf("expected IssuesTimeOpenAveragePerformanceOverview=272.41 but got %f", p.IssuesTimeOpenAveragePerformanceOverview)
+				if p.IssuesTimeOpenAveragePerformanceOverview == nil || *p.IssuesTimeOpenAveragePerformanceOverview != 272.41 {
+					t.Fatalf("expected IssuesTimeOpenAveragePerformanceOverview=272.41 but got %v", p.IssuesTimeOpenAveragePerformanceOverview)
 				}
 			},
 		},
@@ -108,7 +108,7 @@ func TestMockHTTPRequests(t *testing.T) {
 			// as the test data does not include any repoURLs
 			repoURLs := []string{}
 
-			url := cauldron.NewURL(2296, "2024-04-01", "2024-04-16", tt.tab, repoURLs)
+			url := cauldron.NewURL(2296, date(innerT, "2024-04-01"), date(innerT, "2024-04-16"), tt.tab, repoURLs)
 			url.Scheme = "http"
 			url.Host = baseURL
 
@@ -184,10 +184,66 @@ func TestNewURL(t *testing.T) {
 		t.Run(testCase.name, func(tt *testing.T) {
 			tt.Parallel()
 
-			url := cauldron.NewURL(testCase.projectID, testCase.from, testCase.to, testCase.tab, testCase.repoURLs)
+			url := cauldron.NewURL(testCase.projectID, date(tt, testCase.from), date(tt, testCase.to), testCase.tab, testCase.repoURLs)
 			if url.String() != testCase.expected {
 				tt.Fatalf("expected %s but got %s", testCase.expected, url.String())
 			}
 		})
 	}
 }
+
+func TestRebase(t *testing.T) {
+	testCases := []struct {
+		name     string
+		base     string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "default",
+			base:     cauldron.DefaultBaseURL,
+			expected: "https://cauldron.io/project/2296/metrics?from=2024-04-01&to=2024-04-16&tab=overview",
+		},
+		{
+			name:     "self-hosted",
+			base:     "http://localhost:8080",
+			expected: "http://localhost:8080/project/2296/metrics?from=2024-04-01&to=2024-04-16&tab=overview",
+		},
+		{
+			name:     "with-path",
+			base:     "https://example.com/cauldron/",
+			expected: "https://example.com/cauldron/project/2296/metrics?from=2024-04-01&to=2024-04-16&tab=overview",
+		},
+		{
+			name: "no-scheme",
+			base: "cauldron.io",
+			err:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			u := cauldron.NewURL(2296, date(tt, "2024-04-01"), date(tt, "2024-04-16"), "overview", []string{})
+
+			rebased, err := cauldron.Rebase(u, testCase.base)
+			if testCase.err {
+				if err == nil {
+					tt.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if rebased.String() != testCase.expected {
+				tt.Fatalf("expected %s but got %s", testCase.expected, rebased.String())
+			}
+		})
+	}
+}
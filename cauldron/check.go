@@ -0,0 +1,116 @@
+package cauldron
+
+import (
+	"strconv"
+
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+)
+
+// CheckResult is the outcome of a rule for a project.
+type CheckResult struct {
+	ProjectID   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Rule        string `json:"rule"`
+	Condition   string `json:"condition"`
+	Key         string `json:"key"`
+	// Value is the value of the metric, nil if it was not reported.
+	Value   *float64 `json:"value"`
+	Status  string   `json:"status"`
+	Message string   `json:"message,omitempty"`
+
+	precision int
+}
+
+// CheckResponse is the JSON document of the outcomes of the rules.
+type CheckResponse struct {
+	SchemaVersion int `json:"schema_version"`
+	// Status is the most severe status of the results.
+	Status  string        `json:"status"`
+	Results []CheckResult `json:"results"`
+}
+
+// Check evaluates the rules applying to a project against its printables.
+func Check(p project.Project, rules []rule.Rule, printables []Printable) ([]CheckResult, error) {
+	values := map[string]*float64{}
+	precisions := map[string]int{}
+	for _, printable := range printables {
+		for _, m := range printable.Metrics() {
+			values[m.Key] = m.Value
+			precisions[m.Key] = m.Precision
+		}
+	}
+
+	results := []CheckResult{}
+	for _, r := range rules {
+		if !r.Applies(p) {
+			continue
+		}
+
+		result, err := r.Evaluate(values)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, CheckResult{
+			ProjectID:   p.ID,
+			ProjectName: p.Name,
+			Rule:        r.Name,
+			Condition:   result.Condition,
+			Key:         result.Key,
+			Value:       result.Value,
+			Status:      result.Status,
+			Message:     result.Message,
+			precision:   precisions[result.Key],
+		})
+	}
+
+	return results, nil
+}
+
+// CheckStatus returns the most severe status of the results.
+func CheckStatus(results []CheckResult) string {
+	statuses := make([]string, len(results))
+	for i, r := range results {
+		statuses[i] = r.Status
+	}
+
+	return rule.Worst(statuses...)
+}
+
+// NewCheckReport renders the outcomes of the rules as a table, highlighting
+// the failures in the console and markdown formats.
+func NewCheckReport(results []CheckResult) Report {
+	status := CheckStatus(results)
+
+	table := Table{
+		Title:   "Checks: " + status,
+		Headers: []string{"Project", "Rule", "Condition", "Value", "Status", "Message"},
+	}
+
+	records := Table{
+		Headers: []string{"project_id", "project_name", "rule", "condition", "metric", "value", "status", "message"},
+	}
+
+	for _, r := range results {
+		value := Metric{Value: r.Value, Precision: r.precision}.String()
+
+		cell := r.Status
+		if r.Status == rule.Fail {
+			cell = "** " + rule.Fail + " **"
+		}
+
+		table.Rows = append(table.Rows, []string{r.ProjectName, r.Rule, r.Condition, value, cell, r.Message})
+		records.Rows = append(records.Rows, []string{strconv.Itoa(r.ProjectID), r.ProjectName, r.Rule, r.Condition, r.Key, value, r.Status, r.Message})
+	}
+
+	return Report{
+		Tables: []Table{table},
+		Document: CheckResponse{
+			SchemaVersion: SchemaVersion,
+			Status:        status,
+			Results:       results,
+		},
+		Records: &records,
+	}
+}
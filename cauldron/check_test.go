@@ -0,0 +1,63 @@
+package cauldron_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+)
+
+func TestCheck(t *testing.T) {
+	p := project.Project{ID: 2296, Name: "testcontainers-go"}
+	rules := []rule.Rule{
+		{Name: "few-commits", Warn: "commits_activity_overview < 100"},
+		{Name: "few-onboardings", Fail: "onboardings_git_community_overview < 2"},
+		{Name: "other-project", Fail: "commits_activity_overview < 1000", Projects: []string{"testcontainers-java"}},
+	}
+
+	printables := []cauldron.Printable{
+		&cauldron.Activity{CommitsActivityOverview: cauldron.Int(50)},
+		&cauldron.Community{OnboardingsGitCommunityOverview: cauldron.Int(3)},
+	}
+
+	results, err := cauldron.Check(p, rules, printables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected the results of the rules applying to the project but got %v", results)
+	}
+
+	if results[0].Status != rule.Warn || *results[0].Value != 50 || results[1].Status != rule.Pass {
+		t.Fatalf("unexpected results %+v", results)
+	}
+
+	if status := cauldron.CheckStatus(results); status != rule.Warn {
+		t.Fatalf("expected warn but got %s", status)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := cauldron.NewCheckReport(results).Write(buf, "csv"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "project_id,project_name,rule,condition,metric,value,status,message\n" +
+		"2296,testcontainers-go,few-commits,commits_activity_overview < 100,commits_activity_overview,50,warn,\n" +
+		"2296,testcontainers-go,few-onboardings,onboardings_git_community_overview < 2,onboardings_git_community_overview,3,pass,\n"
+	if buf.String() != expected {
+		t.Fatalf("expected\n%s\nbut got\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := cauldron.NewCheckReport(results).Write(buf, "json"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `"status": "warn"`) {
+		t.Fatalf("expected the overall status but got %s", buf.String())
+	}
+}
@@ -0,0 +1,163 @@
+package cauldron
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+const (
+	// Better is the status of a metric that improved between two periods
+	Better = "better"
+	// Worse is the status of a metric that regressed between two periods
+	Worse = "worse"
+	// Unchanged is the status of a metric with the same value in both periods
+	Unchanged = "unchanged"
+)
+
+// Comparison is the change of a metric between two periods, A and B.
+type Comparison struct {
+	Key   string   `json:"key"`
+	Name  string   `json:"name"`
+	A     *float64 `json:"a"`
+	B     *float64 `json:"b"`
+	Delta *float64 `json:"delta"`
+	// Change is the percentage change from A to B, nil if A is zero.
+	Change *float64 `json:"change"`
+	// Status is better, worse or unchanged, according to the direction of
+	// the metric, or empty if any of the values is missing.
+	Status string `json:"status"`
+
+	precision int
+}
+
+// Compare matches the metrics of a tab fetched for two periods.
+func Compare(a Printable, b Printable) []Comparison {
+	bMetrics := map[string]Metric{}
+	for _, m := range b.Metrics() {
+		bMetrics[m.Key] = m
+	}
+
+	comparisons := []Comparison{}
+	for _, ma := range a.Metrics() {
+		mb := bMetrics[ma.Key]
+		comparisons = append(comparisons, NewComparison(ma.Key, ma.Name, ma.Value, mb.Value, ma.Precision))
+	}
+
+	return comparisons
+}
+
+// NewComparison calculates the change of a metric between the values of two periods.
+func NewComparison(key string, name string, a *float64, b *float64, precision int) Comparison {
+	c := Comparison{Key: key, Name: name, A: a, B: b, precision: precision}
+	if a == nil || b == nil {
+		return c
+	}
+
+	delta := *b - *a
+	c.Delta = &delta
+
+	if *a != 0 {
+		change := delta / *a * 100
+		c.Change = &change
+	}
+
+	switch {
+	case delta == 0:
+		c.Status = Unchanged
+	case (delta < 0) == LowerIsBetter(key):
+		c.Status = Better
+	default:
+		c.Status = Worse
+	}
+
+	return c
+}
+
+// Regression returns true if the metric got worse from A to B.
+func (c Comparison) Regression() bool {
+	return c.Status == Worse
+}
+
+// Cells renders the values of the comparison: A, B, delta, change and status.
+func (c Comparison) Cells() []string {
+	change := NotAvailable
+	if c.Change != nil {
+		change = fmt.Sprintf("%+.2f%%", *c.Change)
+	}
+
+	delta := NotAvailable
+	if c.Delta != nil {
+		delta = strconv.FormatFloat(*c.Delta, 'f', c.precision, 64)
+		if *c.Delta > 0 {
+			delta = "+" + delta
+		}
+	}
+
+	status := c.Status
+	if c.Regression() {
+		// highlight the regressions, so they stand out in the tables
+		status = "** " + Worse + " **"
+	}
+
+	return []string{
+		Metric{Value: c.A, Precision: c.precision}.String(),
+		Metric{Value: c.B, Precision: c.precision}.String(),
+		delta,
+		change,
+		status,
+	}
+}
+
+// ComparisonResponse is the JSON document of the comparison of a tab.
+type ComparisonResponse struct {
+	SchemaVersion int             `json:"schema_version"`
+	Project       project.Project `json:"project"`
+	FromA         string          `json:"from_a"`
+	ToA           string          `json:"to_a"`
+	FromB         string          `json:"from_b"`
+	ToB           string          `json:"to_b"`
+	Tab           string          `json:"tab"`
+	Comparisons   []Comparison    `json:"comparisons"`
+}
+
+// NewComparisonReport renders the comparison of a tab between two periods.
+func NewComparisonReport(p project.Project, a period.Period, b period.Period, tab string, comparisons []Comparison) Report {
+	table := Table{
+		Title:   fmt.Sprintf("Project: %s (%d), Tab: %s, A: %s, B: %s", p.Name, p.ID, tab, a, b),
+		Headers: []string{"Metric (" + p.Name + ")", "A", "B", "Delta", "Change", "Status"},
+	}
+
+	records := Table{
+		Headers: []string{"project_id", "project_name", "tab", "metric", "a", "b", "delta", "change", "status"},
+	}
+
+	for _, c := range comparisons {
+		cells := c.Cells()
+		table.Rows = append(table.Rows, append([]string{c.Name}, cells...))
+
+		change := NotAvailable
+		if c.Change != nil {
+			change = strconv.FormatFloat(*c.Change, 'f', 2, 64)
+		}
+
+		records.Rows = append(records.Rows, []string{strconv.Itoa(p.ID), p.Name, tab, c.Key, cells[0], cells[1], cells[2], change, c.Status})
+	}
+
+	return Report{
+		Tables: []Table{table},
+		Document: ComparisonResponse{
+			SchemaVersion: SchemaVersion,
+			Project:       p,
+			FromA:         a.From.Format(period.Layout),
+			ToA:           a.To.Format(period.Layout),
+			FromB:         b.From.Format(period.Layout),
+			ToB:           b.To.Format(period.Layout),
+			Tab:           tab,
+			Comparisons:   comparisons,
+		},
+		Records: &records,
+	}
+}
@@ -0,0 +1,59 @@
+package cauldron_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+)
+
+func TestCompare(t *testing.T) {
+	a := &cauldron.Performance{
+		IssuesTimeOpenAveragePerformanceOverview: cauldron.Float(200),
+		OpenIssuesPerformanceOverview:            cauldron.Int(60),
+		OpenReviewsPerformanceOverview:           cauldron.Int(0),
+	}
+	b := &cauldron.Performance{
+		IssuesTimeOpenAveragePerformanceOverview: cauldron.Float(250),
+		OpenIssuesPerformanceOverview:            cauldron.Int(45),
+		OpenReviewsPerformanceOverview:           cauldron.Int(3),
+		ReviewsTimeOpenMedianPerformanceOverview: cauldron.Float(47.8),
+	}
+
+	comparisons := map[string]cauldron.Comparison{}
+	for _, c := range cauldron.Compare(a, b) {
+		comparisons[c.Key] = c
+	}
+
+	t.Run("regression", func(tt *testing.T) {
+		c := comparisons["issues_time_open_average_performance_overview"]
+		if c.Status != cauldron.Worse || *c.Delta != 50 || *c.Change != 25 {
+			tt.Fatalf("unexpected comparison %+v", c)
+		}
+	})
+
+	t.Run("improvement", func(tt *testing.T) {
+		c := comparisons["open_issues_performance_overview"]
+		if c.Status != cauldron.Better || *c.Delta != -15 || *c.Change != -25 {
+			tt.Fatalf("unexpected comparison %+v", c)
+		}
+	})
+
+	t.Run("zero-base", func(tt *testing.T) {
+		c := comparisons["open_reviews_performance_overview"]
+		if c.Change != nil || *c.Delta != 3 {
+			tt.Fatalf("unexpected comparison %+v", c)
+		}
+	})
+
+	t.Run("missing", func(tt *testing.T) {
+		c := comparisons["reviews_time_open_median_performance_overview"]
+		if c.Delta != nil || c.Status != "" {
+			tt.Fatalf("unexpected comparison %+v", c)
+		}
+
+		cells := c.Cells()
+		if cells[0] != "n/a" || cells[1] != "47.80" || cells[3] != "n/a" {
+			tt.Fatalf("unexpected cells %v", cells)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package cauldron
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mdelapenya/cauldrongo/compute"
+)
+
+// ComputedTab is the pseudo-tab of the metrics computed from the fetched tabs
+// with the expressions of the configuration file.
+const ComputedTab = "computed"
+
+// Computed is the printable of the computed metrics, in their configured order.
+type Computed struct {
+	metrics []Metric
+}
+
+// NewComputed evaluates the computed metrics over the metrics of the
+// printables. A computed metric can reference the ones defined before it.
+func NewComputed(defs []compute.Metric, printables []Printable) (*Computed, error) {
+	values := map[string]*float64{}
+	for _, p := range printables {
+		for _, m := range p.Metrics() {
+			values[m.Key] = m.Value
+		}
+	}
+
+	c := &Computed{metrics: make([]Metric, 0, len(defs))}
+	for _, def := range defs {
+		e, err := compute.Parse(def.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		name := def.Name
+		if name == "" {
+			name = MetricName(def.Key)
+		}
+
+		m := Metric{Key: def.Key, Name: name, Value: e.Eval(values), Precision: def.Digits()}
+		values[m.Key] = m.Value
+		c.metrics = append(c.metrics, m)
+	}
+
+	return c, nil
+}
+
+func (c *Computed) Data() [][]string {
+	return rows(c.Metrics())
+}
+
+func (c *Computed) Metrics() []Metric {
+	return c.metrics
+}
+
+// MarshalJSON renders the computed metrics as an object keyed by their JSON
+// keys, with null for the ones not available.
+func (c Computed) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+
+	for i, m := range c.metrics {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(m.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
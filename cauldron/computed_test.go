@@ -0,0 +1,73 @@
+package cauldron_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/compute"
+)
+
+func TestComputed(t *testing.T) {
+	defs := []compute.Metric{
+		{Key: "issue_closure_ratio", Expr: "issues_closed_activity_overview / issues_created_activity_overview"},
+		{Key: "closure_percentage", Name: "Closure %", Expr: "issue_closure_ratio * 100", Precision: cauldron.Int(0)},
+		{Key: "commits_per_onboarding", Expr: "commits_activity_overview / onboardings_git_community_overview"},
+	}
+
+	printables := []cauldron.Printable{
+		&cauldron.Activity{IssuesClosedActivityOverview: cauldron.Int(3), IssuesCreatedActivityOverview: cauldron.Int(4), CommitsActivityOverview: cauldron.Int(10)},
+		&cauldron.Community{OnboardingsGitCommunityOverview: cauldron.Int(0)},
+	}
+
+	c, err := cauldron.NewComputed(defs, printables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := c.Data()
+	expected := [][]string{
+		{"Issue Closure Ratio", "0.75"},
+		{"Closure %", "75"},
+		{"Commits Per Onboarding", cauldron.NotAvailable},
+	}
+
+	if len(data) != len(expected) {
+		t.Fatalf("expected %v but got %v", expected, data)
+	}
+
+	for i := range expected {
+		if data[i][0] != expected[i][0] || data[i][1] != expected[i][1] {
+			t.Fatalf("expected %v but got %v", expected[i], data[i])
+		}
+	}
+
+	bs, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(bs) != `{"issue_closure_ratio":0.75,"closure_percentage":75,"commits_per_onboarding":null}` {
+		t.Fatalf("unexpected JSON %s", bs)
+	}
+
+	if _, err := cauldron.NewComputed([]compute.Metric{{Key: "invalid", Expr: "1 +"}}, printables); err == nil {
+		t.Fatal("expected an error for an invalid expression")
+	}
+}
+
+func TestTabOf(t *testing.T) {
+	testCases := map[string]string{
+		"issues_closed_activity_overview":    "activity-overview",
+		"onboardings_git_community_overview": "community-overview",
+		"commits_overview":                   "overview",
+		"open_issues_performance_overview":   "performance-overview",
+		"stars_github_overview":              "",
+	}
+
+	for key, expected := range testCases {
+		if got := cauldron.TabOf(key); got != expected {
+			t.Errorf("expected tab %q for %s but got %q", expected, key, got)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package cauldron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Result is a decoded tab response, along with the differences between the
+// fields in the response and the fields the tab is expected to have.
+type Result struct {
+	Tab       string
+	Printable Printable
+	// Unknown are the fields in the response that the tab doesn't model.
+	// They are kept in the Extra map of the tab.
+	Unknown []string
+	// Missing are the expected fields of the tab absent in the response.
+	Missing []string
+	// Invalid are the expected fields of the tab whose values are not
+	// numbers, e.g. numeric strings that fail to parse.
+	Invalid []string
+	// Raw is the response, as returned by Cauldron.
+	Raw json.RawMessage
+}
+
+// Drifted returns true if the response doesn't match the expected fields of the tab.
+func (r *Result) Drifted() bool {
+	return len(r.Unknown) > 0 || len(r.Missing) > 0 || len(r.Invalid) > 0
+}
+
+// DriftError returns an error describing the drift, or nil if there is none.
+func (r *Result) DriftError() error {
+	if !r.Drifted() {
+		return nil
+	}
+
+	return fmt.Errorf("schema drift in tab %s: unknown fields %v, missing fields %v, invalid fields %v", r.Tab, r.Unknown, r.Missing, r.Invalid)
+}
+
+// NewPrintable returns an empty Printable for the given tab. The tabs without
+// a dedicated struct are decoded into a Generic metric set.
+func NewPrintable(tab string) Printable {
+	switch tab {
+	case "activity-overview":
+		return &Activity{}
+	case "community-overview":
+		return &Community{}
+	case "", "overview":
+		return &Overview{}
+	case "performance-overview":
+		return &Performance{}
+	default:
+		return &Generic{}
+	}
+}
+
+// KnownTabs are the tabs with a dedicated struct.
+var KnownTabs = []string{"activity-overview", "community-overview", "overview", "performance-overview"}
+
+// TabOf returns the known tab reporting the metric with the given JSON key,
+// or empty if none does.
+func TabOf(key string) string {
+	for _, tab := range KnownTabs {
+		for _, m := range NewPrintable(tab).Metrics() {
+			if m.Key == key {
+				return tab
+			}
+		}
+	}
+
+	return ""
+}
+
+// extensible is implemented by the tabs able to keep the fields of the
+// response they don't model.
+type extensible interface {
+	setExtra(map[string]any)
+}
+
+// Decode unmarshals the response of a tab, detecting the unknown, missing and
+// invalid fields.
+func Decode(tab string, bs []byte) (*Result, error) {
+	printable := NewPrintable(tab)
+
+	if err := json.Unmarshal(bs, printable); err != nil {
+		return nil, fmt.Errorf("error unmarshalling metrics: %w", err)
+	}
+
+	raw := map[string]any{}
+	if err := json.Unmarshal(bs, &raw); err != nil {
+		return nil, fmt.Errorf("error unmarshalling metrics: %w", err)
+	}
+
+	result := &Result{Tab: tab, Printable: printable, Raw: bs}
+
+	// the generic tabs have no expected fields, nor types
+	_, generic := printable.(*Generic)
+
+	expected := map[string]bool{}
+	for _, m := range printable.Metrics() {
+		expected[m.Key] = true
+
+		v, ok := raw[m.Key]
+		switch {
+		case !ok:
+			result.Missing = append(result.Missing, m.Key)
+		case v != nil && m.Value == nil && !generic:
+			result.Invalid = append(result.Invalid, m.Key)
+		}
+	}
+
+	extra := map[string]any{}
+	for k, v := range raw {
+		if expected[k] || chart(k) {
+			continue
+		}
+
+		extra[k] = v
+		if !unsupported(k) {
+			result.Unknown = append(result.Unknown, k)
+		}
+	}
+	sort.Strings(result.Unknown)
+
+	if e, ok := printable.(extensible); ok && len(extra) > 0 {
+		e.setExtra(extra)
+	}
+
+	return result, nil
+}
+
+// chart returns true for the Bokeh charts of the responses, e.g.
+// commits_activity_overview_bokeh, which are dropped as they are not metrics.
+func chart(key string) bool {
+	return slices.Contains(strings.Split(key, "_"), "bokeh")
+}
+
+// unsupported returns true for the fields of the responses that are not
+// supported as metrics, like the StackExchange questions, which are "?" unless
+// it's a data source of the project. They are kept, but not printed.
+func unsupported(key string) bool {
+	return strings.HasPrefix(key, "questions_") || strings.HasPrefix(key, "question_")
+}
+
+// Fetch requests the given metrics URL and decodes its response.
+func Fetch(u url.URL, opts ...RequestOption) (*Result, error) {
+	reader, code, err := HttpRequest(u, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching metrics: %w. URL: %s", err, u.String())
+	}
+	defer reader.Close()
+
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("error fetching metrics: HTTP status code %d. URL: %s", code, u.String())
+	}
+
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading metrics: %w", err)
+	}
+
+	return Decode(u.Query().Get("tab"), bs)
+}
+
+// marshalWithExtra marshals v, which must marshal to a JSON object, appending
+// the extra fields after the modelled ones.
+func marshalWithExtra(v any, extra map[string]any) ([]byte, error) {
+	bs, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return bs, err
+	}
+
+	extraBytes, err := json.Marshal(extra)
+	if err != nil {
+		return nil, err
+	}
+
+	bs = bytes.TrimSuffix(bs, []byte("}"))
+	if len(bs) > 1 {
+		bs = append(bs, ',')
+	}
+
+	return append(bs, extraBytes[1:]...), nil
+}
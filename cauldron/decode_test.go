@@ -0,0 +1,209 @@
+package cauldron_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+)
+
+func TestDecode(t *testing.T) {
+	t.Run("no-drift", func(tt *testing.T) {
+		bs := []byte(`{
+			"active_people_git_community_overview": 8,
+			"active_people_issues_community_overview": 6,
+			"active_people_patches_community_overview": 8,
+			"onboardings_git_community_overview": 2,
+			"onboardings_issues_community_overview": 4,
+			"onboardings_patches_community_overview": null
+		}`)
+
+		result, err := cauldron.Decode("community-overview", bs)
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		if result.Drifted() {
+			tt.Fatalf("expected no drift but got %v", result.DriftError())
+		}
+
+		c := result.Printable.(*cauldron.Community)
+		if c.OnboardingsPatchesCommunityOverview != nil {
+			tt.Fatalf("expected OnboardingsPatchesCommunityOverview=nil but got %d", *c.OnboardingsPatchesCommunityOverview)
+		}
+	})
+
+	t.Run("drift", func(tt *testing.T) {
+		bs := []byte(`{
+			"issues_time_open_average_performance_overview": 272.41,
+			"issues_time_open_median_performance_overview": 209.19,
+			"open_issues_performance_overview": 66,
+			"reviews_time_open_average_performance_overview": 71.03,
+			"open_reviews_performance_overview": 27,
+			"reviews_time_to_merge_performance_overview": 3.5
+		}`)
+
+		result, err := cauldron.Decode("performance-overview", bs)
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		if !result.Drifted() {
+			tt.Fatal("expected drift")
+		}
+
+		if !reflect.DeepEqual(result.Unknown, []string{"reviews_time_to_merge_performance_overview"}) {
+			tt.Fatalf("unexpected unknown fields %v", result.Unknown)
+		}
+
+		if !reflect.DeepEqual(result.Missing, []string{"reviews_time_open_median_performance_overview"}) {
+			tt.Fatalf("unexpected missing fields %v", result.Missing)
+		}
+
+		bs, err = json.Marshal(result.Printable)
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		expected := `{"issues_time_open_average_performance_overview":272.41,"issues_time_open_median_performance_overview":209.19,"open_issues_performance_overview":66,"reviews_time_open_average_performance_overview":71.03,"reviews_time_open_median_performance_overview":null,"open_reviews_performance_overview":27,"reviews_time_to_merge_performance_overview":3.5}`
+		if string(bs) != expected {
+			tt.Fatalf("expected %s but got %s", expected, string(bs))
+		}
+	})
+}
+
+func TestDecodeFixtures(t *testing.T) {
+	testCases := []struct {
+		file string
+		tab  string
+	}{
+		{file: "activity.json", tab: "activity-overview"},
+		{file: "community.json", tab: "community-overview"},
+		{file: "overview.json", tab: "overview"},
+		{file: "performance.json", tab: "performance-overview"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.tab, func(tt *testing.T) {
+			tt.Parallel()
+
+			bs, err := os.ReadFile(filepath.Join("..", "testdata", testCase.file))
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			// the fixtures are the mappings of the mock server
+			mapping := struct {
+				Response struct {
+					JSONBody json.RawMessage `json:"jsonBody"`
+				} `json:"response"`
+			}{}
+			if err := json.Unmarshal(bs, &mapping); err != nil {
+				tt.Fatal(err)
+			}
+
+			result, err := cauldron.Decode(testCase.tab, mapping.Response.JSONBody)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if result.Drifted() {
+				tt.Fatalf("expected no drift but got %v", result.DriftError())
+			}
+
+			out, err := json.Marshal(result.Printable)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if strings.Contains(string(out), "bokeh") {
+				tt.Fatalf("expected no charts in the output but got %s", out)
+			}
+
+			// the other fields are kept, including the unsupported ones
+			raw := map[string]any{}
+			if err := json.Unmarshal(mapping.Response.JSONBody, &raw); err != nil {
+				tt.Fatal(err)
+			}
+
+			for k := range raw {
+				if !slices.Contains(strings.Split(k, "_"), "bokeh") && !strings.Contains(string(out), `"`+k+`"`) {
+					tt.Fatalf("expected %s in the output but got %s", k, out)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	bs := []byte(`{
+		"commits_activity_overview": 10,
+		"lines_commit_activity_overview": "12,5",
+		"lines_commit_file_activity_overview": "163.13",
+		"issues_created_activity_overview": 1,
+		"issues_closed_activity_overview": 1,
+		"issues_open_activity_overview": 0,
+		"reviews_created_activity_overview": 2,
+		"reviews_closed_activity_overview": 2,
+		"reviews_open_activity_overview": null
+	}`)
+
+	result, err := cauldron.Decode("activity-overview", bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Drifted() {
+		t.Fatal("expected drift for an unparseable numeric string")
+	}
+
+	if !reflect.DeepEqual(result.Invalid, []string{"lines_commit_activity_overview"}) {
+		t.Fatalf("unexpected invalid fields %v", result.Invalid)
+	}
+}
+
+func TestDecodeGeneric(t *testing.T) {
+	bs := []byte(`{
+		"forks_github_overview": 120,
+		"stars_github_overview": 3550,
+		"lines_commit_github_overview": "12.5",
+		"issues_median_time_github_overview": null,
+		"questions_github_overview": "?",
+		"stars_github_overview_bokeh": {"doc": {}}
+	}`)
+
+	result, err := cauldron.Decode("github-overview", bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Drifted() {
+		t.Fatalf("expected no drift but got %v", result.DriftError())
+	}
+
+	expectedData := [][]string{
+		{"Forks Github Overview", "120"},
+		{"Stars Github Overview", "3550"},
+		{"Lines Commit Github Overview", "12.50"},
+		{"Issues Median Time Github Overview", "n/a"},
+	}
+	if !reflect.DeepEqual(result.Printable.Data(), expectedData) {
+		t.Fatalf("expected %v but got %v", expectedData, result.Printable.Data())
+	}
+
+	bs, err = json.Marshal(result.Printable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"forks_github_overview":120,"stars_github_overview":3550,"lines_commit_github_overview":"12.5","issues_median_time_github_overview":null,"questions_github_overview":"?"}`
+	if string(bs) != expected {
+		t.Fatalf("expected %s but got %s", expected, string(bs))
+	}
+}
@@ -0,0 +1,50 @@
+package cauldron
+
+import (
+	"github.com/mdelapenya/cauldrongo/compute"
+)
+
+// DerivedTab is the pseudo-tab of the built-in community health indicators,
+// derived from the known tabs.
+const DerivedTab = "derived"
+
+// DerivedMetrics are the indicators of the derived pseudo-tab. They are not
+// available if a metric they use is not reported or they divide by zero.
+var DerivedMetrics = []compute.Metric{
+	{
+		Key:  "issue_closure_ratio_derived",
+		Name: "Issue Closure Ratio",
+		Expr: "issues_closed_activity_overview / issues_created_activity_overview",
+	},
+	{
+		Key:  "review_merge_throughput_derived",
+		Name: "Review Merge Throughput",
+		Expr: "reviews_closed_activity_overview / reviews_created_activity_overview",
+	},
+	{
+		Key:  "onboarding_rate_derived",
+		Name: "Onboarding Rate",
+		Expr: "onboardings_git_community_overview / active_people_git_community_overview",
+	},
+	{
+		Key:  "open_backlog_per_active_person_derived",
+		Name: "Open Backlog per Active Person",
+		Expr: "(open_issues_performance_overview + open_reviews_performance_overview) / active_people_git_community_overview",
+	},
+	{
+		Key:  "review_issue_time_to_close_ratio_derived",
+		Name: "Review vs Issue Time to Close Ratio",
+		Expr: "reviews_median_time_to_close_overview / issues_median_time_to_close_overview",
+	},
+}
+
+// IsDerived returns true if the key is the one of a derived indicator.
+func IsDerived(key string) bool {
+	for _, m := range DerivedMetrics {
+		if m.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package cauldron_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+)
+
+func TestDerived(t *testing.T) {
+	printables := []cauldron.Printable{
+		&cauldron.Activity{
+			IssuesCreatedActivityOverview:  cauldron.Int(40),
+			IssuesClosedActivityOverview:   cauldron.Int(30),
+			ReviewsCreatedActivityOverview: cauldron.Int(0),
+			ReviewsClosedActivityOverview:  cauldron.Int(5),
+		},
+		&cauldron.Community{
+			ActivePeopleGitCommunityOverview: cauldron.Int(10),
+			OnboardingsGitCommunityOverview:  cauldron.Int(2),
+		},
+		&cauldron.Performance{
+			OpenIssuesPerformanceOverview:  cauldron.Int(12),
+			OpenReviewsPerformanceOverview: cauldron.Int(8),
+		},
+	}
+
+	derived, err := cauldron.NewComputed(cauldron.DerivedMetrics, printables)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"issue_closure_ratio_derived": "0.75",
+		// no reviews created, instead of infinite
+		"review_merge_throughput_derived":        cauldron.NotAvailable,
+		"onboarding_rate_derived":                "0.20",
+		"open_backlog_per_active_person_derived": "2.00",
+		// the overview tab was not fetched
+		"review_issue_time_to_close_ratio_derived": cauldron.NotAvailable,
+	}
+
+	metrics := derived.Metrics()
+	if len(metrics) != len(expected) {
+		t.Fatalf("expected %d indicators but got %d", len(expected), len(metrics))
+	}
+
+	for _, m := range metrics {
+		if got := m.String(); got != expected[m.Key] {
+			t.Errorf("expected %s for %s but got %s", expected[m.Key], m.Key, got)
+		}
+
+		if !cauldron.IsDerived(m.Key) {
+			t.Errorf("expected %s to be derived", m.Key)
+		}
+	}
+
+	if cauldron.IsDerived("commits_overview") {
+		t.Error("expected commits_overview not to be derived")
+	}
+
+	if !cauldron.LowerIsBetter("open_backlog_per_active_person_derived") || !cauldron.LowerIsBetter("review_issue_time_to_close_ratio_derived") {
+		t.Error("expected the backlog and the time to close ratio to be better when lower")
+	}
+}
@@ -0,0 +1,107 @@
+package cauldron
+
+import (
+	"encoding/json"
+)
+
+// BreachMarker is appended to the values worse than their threshold.
+const BreachMarker = " (!)"
+
+// Select returns a view of the printable with only the metrics with the
+// given JSON keys, in their original order.
+func Select(p Printable, keys []string) Printable {
+	selected := map[string]bool{}
+	for _, k := range keys {
+		selected[k] = true
+	}
+
+	return &selection{Printable: p, keys: selected}
+}
+
+type selection struct {
+	Printable
+	keys map[string]bool
+}
+
+func (s *selection) Data() [][]string {
+	return rows(s.Metrics())
+}
+
+func (s *selection) Metrics() []Metric {
+	metrics := []Metric{}
+	for _, m := range s.Printable.Metrics() {
+		if s.keys[m.Key] {
+			metrics = append(metrics, m)
+		}
+	}
+
+	return metrics
+}
+
+func (s *selection) MarshalJSON() ([]byte, error) {
+	bs, err := json.Marshal(s.Printable)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Generic{}
+	if err := json.Unmarshal(bs, g); err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, k := range g.Keys {
+		if s.keys[k] {
+			keys = append(keys, k)
+		}
+	}
+	g.Keys = keys
+
+	return json.Marshal(g)
+}
+
+// Breached returns true if the value is worse than the limit, according to
+// the direction of the metric.
+func Breached(key string, value float64, limit float64) bool {
+	if LowerIsBetter(key) {
+		return value > limit
+	}
+
+	return value < limit
+}
+
+// breach returns the marker of a value worse than the limit of the metric,
+// or an empty string.
+func breach(limits map[string]float64, key string, value *float64) string {
+	limit, ok := limits[key]
+	if ok && value != nil && Breached(key, *value, limit) {
+		return BreachMarker
+	}
+
+	return ""
+}
+
+// WithThresholds returns a view of the printable flagging the values worse
+// than their limits, keyed by the JSON key of the metrics, in the displayed
+// data. The metrics and the JSON representation are not modified.
+func WithThresholds(p Printable, limits map[string]float64) Printable {
+	return &thresholds{Printable: p, limits: limits}
+}
+
+type thresholds struct {
+	Printable
+	limits map[string]float64
+}
+
+func (t *thresholds) Data() [][]string {
+	data := [][]string{}
+	for _, m := range t.Metrics() {
+		data = append(data, []string{m.Name, m.String() + breach(t.limits, m.Key, m.Value)})
+	}
+
+	return data
+}
+
+func (t *thresholds) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Printable)
+}
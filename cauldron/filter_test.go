@@ -0,0 +1,71 @@
+package cauldron_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+)
+
+func TestSelect(t *testing.T) {
+	o := &cauldron.Overview{
+		CommitsOverview:                 cauldron.Int(1581),
+		IssuesOverview:                  cauldron.Int(386),
+		IssuesMedianTimeToCloseOverview: cauldron.Float(18),
+	}
+
+	selected := cauldron.Select(o, []string{"issues_median_time_to_close_overview", "commits_overview"})
+
+	expectedData := [][]string{
+		{"Commits Overview", "1581"},
+		{"Issues Median Time To Close Overview", "18.00"},
+	}
+	if !reflect.DeepEqual(selected.Data(), expectedData) {
+		t.Fatalf("expected %v but got %v", expectedData, selected.Data())
+	}
+
+	bs, err := json.Marshal(selected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"commits_overview":1581,"issues_median_time_to_close_overview":18}`
+	if string(bs) != expected {
+		t.Fatalf("expected %s but got %s", expected, string(bs))
+	}
+}
+
+func TestWithThresholds(t *testing.T) {
+	c := &cauldron.Performance{
+		IssuesTimeOpenMedianPerformanceOverview: cauldron.Float(209.19),
+		OpenIssuesPerformanceOverview:           cauldron.Int(66),
+		OpenReviewsPerformanceOverview:          cauldron.Int(27),
+	}
+
+	limits := map[string]float64{
+		// lower is better, so values above the limits are breaches
+		"issues_time_open_median_performance_overview":  180,
+		"open_issues_performance_overview":              100,
+		"reviews_time_open_median_performance_overview": 10,
+	}
+
+	printable := cauldron.WithThresholds(cauldron.Select(c, []string{
+		"issues_time_open_median_performance_overview",
+		"open_issues_performance_overview",
+		"reviews_time_open_median_performance_overview",
+	}), limits)
+
+	expected := [][]string{
+		{"Issues Time Open Median Performance Overview", "209.19 (!)"},
+		{"Open Issues Performance Overview", "66"},
+		{"Reviews Time Open Median Performance Overview", "n/a"},
+	}
+	if !reflect.DeepEqual(printable.Data(), expected) {
+		t.Fatalf("expected %v but got %v", expected, printable.Data())
+	}
+
+	if !cauldron.Breached("onboardings_git_community_overview", 1, 2) {
+		t.Fatal("expected a breach for a higher is better metric below its limit")
+	}
+}
@@ -1,10 +1,14 @@
 package cauldron
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"time"
 
+	"github.com/mdelapenya/cauldrongo/period"
 	"github.com/mdelapenya/cauldrongo/project"
 	"github.com/olekukonko/tablewriter"
 )
@@ -13,7 +17,7 @@ type Formatter interface {
 	Format(Printable) error
 }
 
-func NewConsoleFormatter(p project.Project, from string, to string, tab string, w io.Writer) *consoleFormatter {
+func NewConsoleFormatter(p project.Project, from time.Time, to time.Time, tab string, w io.Writer) *consoleFormatter {
 	return &consoleFormatter{
 		Project: p,
 		From:    from,
@@ -24,8 +28,8 @@ func NewConsoleFormatter(p project.Project, from string, to string, tab string,
 }
 
 type consoleFormatter struct {
-	From    string
-	To      string
+	From    time.Time
+	To      time.Time
 	Tab     string
 	Writer  io.Writer
 	Project project.Project
@@ -34,8 +38,8 @@ type consoleFormatter struct {
 func (c *consoleFormatter) Format(p Printable) error {
 	fmt.Fprintf(c.Writer, "Project: %s (%d)\n", c.Project.Name, c.Project.ID)
 	fmt.Fprintf(c.Writer, "Repo URLs: %v\n", c.Project.RepoURL)
-	fmt.Fprintf(c.Writer, "From: %s\n", c.From)
-	fmt.Fprintf(c.Writer, "To: %s\n", c.To)
+	fmt.Fprintf(c.Writer, "From: %s\n", c.From.Format(period.Layout))
+	fmt.Fprintf(c.Writer, "To: %s\n", c.To.Format(period.Layout))
 	fmt.Fprintf(c.Writer, "Tab: %s\n", c.Tab)
 
 	table := tablewriter.NewWriter(c.Writer)
@@ -57,7 +61,7 @@ func (c *consoleFormatter) Format(p Printable) error {
 	return nil
 }
 
-func NewJSONFormatter(p project.Project, from string, to string, tab string, indent string, w io.Writer) *jsonFormatter {
+func NewJSONFormatter(p project.Project, from time.Time, to time.Time, tab string, indent string, w io.Writer) *jsonFormatter {
 	if len(indent) == 0 {
 		indent = "  "
 	}
@@ -74,19 +78,20 @@ func NewJSONFormatter(p project.Project, from string, to string, tab string, ind
 
 type jsonFormatter struct {
 	Indent  string
-	From    string
-	To      string
+	From    time.Time
+	To      time.Time
 	Tab     string
 	Writer  io.Writer
 	Project project.Project
 }
 
 type JSONResponse struct {
-	Project  project.Project `json:"project"`
-	From     string          `json:"from"`
-	To       string          `json:"to"`
-	Tab      string          `json:"tab"`
-	Response Printable       `json:"response"`
+	SchemaVersion int             `json:"schema_version"`
+	Project       project.Project `json:"project"`
+	From          string          `json:"from"`
+	To            string          `json:"to"`
+	Tab           string          `json:"tab"`
+	Response      Printable       `json:"response"`
 }
 
 func (j *jsonFormatter) Format(p Printable) error {
@@ -96,11 +101,12 @@ func (j *jsonFormatter) Format(p Printable) error {
 	}
 
 	resp := JSONResponse{
-		Project:  j.Project,
-		From:     j.From,
-		To:       j.To,
-		Tab:      j.Tab,
-		Response: p,
+		SchemaVersion: SchemaVersion,
+		Project:       j.Project,
+		From:          j.From.Format(period.Layout),
+		To:            j.To.Format(period.Layout),
+		Tab:           j.Tab,
+		Response:      p,
 	}
 
 	bs, err := json.MarshalIndent(resp, "", j.Indent)
@@ -113,3 +119,127 @@ func (j *jsonFormatter) Format(p Printable) error {
 	_, err = j.Writer.Write(bs)
 	return err
 }
+
+func NewMarkdownFormatter(p project.Project, from time.Time, to time.Time, tab string, w io.Writer) *markdownFormatter {
+	return &markdownFormatter{
+		Project: p,
+		From:    from,
+		To:      to,
+		Tab:     tab,
+		Writer:  w,
+	}
+}
+
+type markdownFormatter struct {
+	From    time.Time
+	To      time.Time
+	Tab     string
+	Writer  io.Writer
+	Project project.Project
+}
+
+func (m *markdownFormatter) Format(p Printable) error {
+	fmt.Fprintf(m.Writer, "## %s (%d): %s\n\n", m.Project.Name, m.Project.ID, m.Tab)
+	fmt.Fprintf(m.Writer, "- Repo URLs: %v\n", m.Project.RepoURL)
+	fmt.Fprintf(m.Writer, "- From: %s\n", m.From.Format(period.Layout))
+	fmt.Fprintf(m.Writer, "- To: %s\n\n", m.To.Format(period.Layout))
+
+	table := tablewriter.NewWriter(m.Writer)
+
+	table.SetHeader([]string{"Metric", "Value"})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT})
+
+	for _, v := range p.Data() {
+		table.Append(v)
+	}
+
+	table.Render()
+	fmt.Fprintln(m.Writer)
+
+	return nil
+}
+
+// CSVHeader is the first record written by the CSV formatter when asked to.
+var CSVHeader = []string{"project_id", "project_name", "from", "to", "tab", "metric", "value"}
+
+// NewCSVFormatter returns a formatter writing one record per metric. As the
+// output of several formatters is usually concatenated, the header record is
+// only written if header is true.
+func NewCSVFormatter(p project.Project, from time.Time, to time.Time, tab string, header bool, w io.Writer) *csvFormatter {
+	return &csvFormatter{
+		Project: p,
+		From:    from,
+		To:      to,
+		Tab:     tab,
+		Header:  header,
+		Writer:  w,
+	}
+}
+
+type csvFormatter struct {
+	From    time.Time
+	To      time.Time
+	Tab     string
+	Header  bool
+	Writer  io.Writer
+	Project project.Project
+}
+
+func (c *csvFormatter) Format(p Printable) error {
+	w := csv.NewWriter(c.Writer)
+
+	if c.Header {
+		if err := w.Write(CSVHeader); err != nil {
+			return fmt.Errorf("error writing CSV: %w", err)
+		}
+	}
+
+	for _, m := range p.Metrics() {
+		// missing metrics are written as empty cells
+		value := ""
+		if m.Value != nil {
+			value = m.String()
+		}
+
+		record := []string{strconv.Itoa(c.Project.ID), c.Project.Name, c.From.Format(period.Layout), c.To.Format(period.Layout), c.Tab, m.Key, value}
+		if err := w.Write(record); err != nil {
+			return fmt.Errorf("error writing CSV: %w", err)
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func NewHTMLFormatter(p project.Project, from time.Time, to time.Time, tab string, w io.Writer) *htmlFormatter {
+	return &htmlFormatter{
+		Project: p,
+		From:    from,
+		To:      to,
+		Tab:     tab,
+		Writer:  w,
+	}
+}
+
+type htmlFormatter struct {
+	From    time.Time
+	To      time.Time
+	Tab     string
+	Writer  io.Writer
+	Project project.Project
+}
+
+func (h *htmlFormatter) Format(p Printable) error {
+	table := Table{
+		Title:   fmt.Sprintf("Project: %s (%d), Tab: %s, From: %s, To: %s", h.Project.Name, h.Project.ID, h.Tab, h.From.Format(period.Layout), h.To.Format(period.Layout)),
+		Headers: []string{"Metric (" + h.Project.Name + ")", "Value"},
+		Rows:    p.Data(),
+	}
+
+	table.writeHTML(h.Writer)
+	return nil
+}
@@ -2,13 +2,26 @@ package cauldron_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
 	"github.com/mdelapenya/cauldrongo/project"
 )
 
 var testProject = project.Project{ID: 1, Name: "Test Project", RepoURL: []string{"http://example.com/repo", "http://example.com/repo.git"}}
 
+func date(t *testing.T, s string) time.Time {
+	t.Helper()
+
+	d, err := time.Parse(period.Layout, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d
+}
+
 type testWriter struct {
 	data []byte
 }
@@ -27,85 +40,85 @@ func TestConsoleFormatter(t *testing.T) {
 	}{
 		{
 			name:      "activity",
-			printable: &cauldron.Activity{CommitsActivityOverview: 15},
+			printable: &cauldron.Activity{CommitsActivityOverview: cauldron.Int(15), IssuesCreatedActivityOverview: cauldron.Int(0)},
 			expected: `+-------------------------------------+-------+
 |        METRIC (TEST PROJECT)        | VALUE |
 +-------------------------------------+-------+
 | Commits Activity Overview           |    15 |
-| Lines Commit Activity Overview      |       |
-| Lines Commit File Activity Overview |       |
+| Lines Commit Activity Overview      |   n/a |
+| Lines Commit File Activity Overview |   n/a |
 | Issues Created Activity Overview    |     0 |
-| Issues Closed Activity Overview     |     0 |
-| Issues Open Activity Overview       |     0 |
-| Reviews Created Activity Overview   |     0 |
-| Reviews Closed Activity Overview    |     0 |
-| Reviews Open Activity Overview      |     0 |
+| Issues Closed Activity Overview     |   n/a |
+| Issues Open Activity Overview       |   n/a |
+| Reviews Created Activity Overview   |   n/a |
+| Reviews Closed Activity Overview    |   n/a |
+| Reviews Open Activity Overview      |   n/a |
 +-------------------------------------+-------+
 `,
 			writer: &testWriter{},
 		},
 		{
 			name:      "community",
-			printable: &cauldron.Community{ActivePeopleGitCommunityOverview: 87},
+			printable: &cauldron.Community{ActivePeopleGitCommunityOverview: cauldron.Int(87)},
 			expected: `+------------------------------------------+-------+
 |          METRIC (TEST PROJECT)           | VALUE |
 +------------------------------------------+-------+
 | Active People Git Community Overview     |    87 |
-| Active People Issues Community Overview  |     0 |
-| Active People Patches Community Overview |     0 |
-| Onboardings Git Community Overview       |     0 |
-| Onboardings Issues Community Overview    |     0 |
-| Onboardings Patches Community Overview   |     0 |
+| Active People Issues Community Overview  |   n/a |
+| Active People Patches Community Overview |   n/a |
+| Onboardings Git Community Overview       |   n/a |
+| Onboardings Issues Community Overview    |   n/a |
+| Onboardings Patches Community Overview   |   n/a |
 +------------------------------------------+-------+
 `,
 			writer: &testWriter{},
 		},
 		{
 			name:      "overview",
-			printable: &cauldron.Overview{CommitsOverview: 1587},
+			printable: &cauldron.Overview{CommitsOverview: cauldron.Int(1587)},
 			expected: `+-------------------------------------------------+-------+
 |              METRIC (TEST PROJECT)              | VALUE |
 +-------------------------------------------------+-------+
 | Commits Overview                                |  1587 |
-| Issues Overview                                 |     0 |
-| Reviews Overview                                |     0 |
-| Commits Last Year Overview                      |     0 |
-| Issues Last Year Overview                       |     0 |
-| Reviews Last Year Overview                      |     0 |
-| Commits YoY Overview                            |  0.00 |
-| Issues YoY Overview                             |  0.00 |
-| Reviews YoY Overview                            |  0.00 |
-| Commit Authors Overview                         |     0 |
-| Issue Submitters Overview                       |     0 |
-| Review Submitters Overview                      |     0 |
-| Commit Authors Last Year Overview               |     0 |
-| Issue Submitters Last Year Overview             |     0 |
-| Review Submitters Last Year Overview            |     0 |
-| Commit Authors YoY Overview                     |  0.00 |
-| Issue Submitters YoY Overview                   |  0.00 |
-| Review Submitters YoY Overview                  |  0.00 |
-| Issues Median Time To Close Overview            |  0.00 |
-| Reviews Median Time To Close Overview           |  0.00 |
-| Issues Median Time To Close Last Year Overview  |  0.00 |
-| Reviews Median Time To Close Last Year Overview |  0.00 |
-| Issues Median Time To Close YoY Overview        |  0.00 |
-| Reviews Median Time To Close YoY Overview       |  0.00 |
+| Issues Overview                                 |   n/a |
+| Reviews Overview                                |   n/a |
+| Commits Last Year Overview                      |   n/a |
+| Issues Last Year Overview                       |   n/a |
+| Reviews Last Year Overview                      |   n/a |
+| Commits YoY Overview                            |   n/a |
+| Issues YoY Overview                             |   n/a |
+| Reviews YoY Overview                            |   n/a |
+| Commit Authors Overview                         |   n/a |
+| Issue Submitters Overview                       |   n/a |
+| Review Submitters Overview                      |   n/a |
+| Commit Authors Last Year Overview               |   n/a |
+| Issue Submitters Last Year Overview             |   n/a |
+| Review Submitters Last Year Overview            |   n/a |
+| Commit Authors YoY Overview                     |   n/a |
+| Issue Submitters YoY Overview                   |   n/a |
+| Review Submitters YoY Overview                  |   n/a |
+| Issues Median Time To Close Overview            |   n/a |
+| Reviews Median Time To Close Overview           |   n/a |
+| Issues Median Time To Close Last Year Overview  |   n/a |
+| Reviews Median Time To Close Last Year Overview |   n/a |
+| Issues Median Time To Close YoY Overview        |   n/a |
+| Reviews Median Time To Close YoY Overview       |   n/a |
 +-------------------------------------------------+-------+
 `,
 			writer: &testWriter{},
 		},
 		{
 			name:      "performance",
-			printable: &cauldron.Performance{IssuesTimeOpenAveragePerformanceOverview: 272.41},
+			printable: &cauldron.Performance{IssuesTimeOpenAveragePerformanceOverview: cauldron.Float(272.41)},
 			expected: `+------------------------------------------------+--------+
 |             METRIC (TEST PROJECT)              | VALUE  |
 +------------------------------------------------+--------+
 | Issues Time Open Average Performance Overview  | 272.41 |
-| Issues Time Open Median Performance Overview   |   0.00 |
-| Open Issues Performance Overview               |      0 |
-| Reviews Time Open Average Performance Overview |   0.00 |
-| Reviews Time Open Median Performance Overview  |   0.00 |
-| Open Reviews Performance Overview              |      0 |
+| Issues Time Open Median Performance Overview   |    n/a |
+| Open Issues Performance Overview               |    n/a |
+| Reviews Time Open Average Performance Overview |    n/a |
+| Reviews Time Open Median Performance Overview  |    n/a |
+| Open Reviews Performance Overview              |    n/a |
 +------------------------------------------------+--------+
 `,
 			writer: &testWriter{},
@@ -117,7 +130,7 @@ func TestConsoleFormatter(t *testing.T) {
 		t.Run(tt.name, func(innerT *testing.T) {
 			innerT.Parallel()
 
-			consoleFormatter := cauldron.NewConsoleFormatter(testProject, "2021-01-01", "2021-12-31", "activity-overview", tt.writer)
+			consoleFormatter := cauldron.NewConsoleFormatter(testProject, date(innerT, "2021-01-01"), date(innerT, "2021-12-31"), "activity-overview", tt.writer)
 
 			err := consoleFormatter.Format(tt.printable)
 			if err != nil {
@@ -144,10 +157,10 @@ Tab: activity-overview
 func TestJSONFormatter(t *testing.T) {
 	w := &testWriter{}
 	// using tab as indent
-	jsonFormatter := cauldron.NewJSONFormatter(testProject, "2021-01-01", "2021-12-31", "activity-overview", "	", w)
+	jsonFormatter := cauldron.NewJSONFormatter(testProject, date(t, "2021-01-01"), date(t, "2021-12-31"), "activity-overview", "	", w)
 
 	a := &cauldron.Activity{}
-	a.CommitsActivityOverview = 15
+	a.CommitsActivityOverview = cauldron.Int(15)
 
 	err := jsonFormatter.Format(a)
 	if err != nil {
@@ -159,6 +172,7 @@ func TestJSONFormatter(t *testing.T) {
 	// the indent is 2 ep
 
 	expected := `{
+	"schema_version": 1,
 	"project": {
 		"id": 1,
 		"name": "Test Project",
@@ -172,14 +186,14 @@ func TestJSONFormatter(t *testing.T) {
 	"tab": "activity-overview",
 	"response": {
 		"commits_activity_overview": 15,
-		"lines_commit_activity_overview": "",
-		"lines_commit_file_activity_overview": "",
-		"issues_created_activity_overview": 0,
-		"issues_closed_activity_overview": 0,
-		"issues_open_activity_overview": 0,
-		"reviews_created_activity_overview": 0,
-		"reviews_closed_activity_overview": 0,
-		"reviews_open_activity_overview": 0
+		"lines_commit_activity_overview": null,
+		"lines_commit_file_activity_overview": null,
+		"issues_created_activity_overview": null,
+		"issues_closed_activity_overview": null,
+		"issues_open_activity_overview": null,
+		"reviews_created_activity_overview": null,
+		"reviews_closed_activity_overview": null,
+		"reviews_open_activity_overview": null
 	}
 }
 `
@@ -187,3 +201,70 @@ func TestJSONFormatter(t *testing.T) {
 		t.Fatalf("expected %s but got %s", expected, formatted)
 	}
 }
+
+func TestMarkdownFormatter(t *testing.T) {
+	w := &testWriter{}
+	markdownFormatter := cauldron.NewMarkdownFormatter(testProject, date(t, "2021-01-01"), date(t, "2021-12-31"), "community-overview", w)
+
+	c := &cauldron.Community{
+		ActivePeopleGitCommunityOverview:   cauldron.Int(8),
+		OnboardingsGitCommunityOverview:    cauldron.Int(0),
+		OnboardingsIssuesCommunityOverview: cauldron.Int(4),
+	}
+
+	err := markdownFormatter.Format(c)
+	if err != nil {
+		t.Fatalf("error formatting: %v", err)
+	}
+
+	formatted := string(w.data)
+
+	expected := `## Test Project (1): community-overview
+
+- Repo URLs: [http://example.com/repo http://example.com/repo.git]
+- From: 2021-01-01
+- To: 2021-12-31
+
+|                  Metric                  | Value |
+|------------------------------------------|-------|
+| Active People Git Community Overview     |     8 |
+| Active People Issues Community Overview  |   n/a |
+| Active People Patches Community Overview |   n/a |
+| Onboardings Git Community Overview       |     0 |
+| Onboardings Issues Community Overview    |     4 |
+| Onboardings Patches Community Overview   |   n/a |
+
+`
+	if formatted != expected {
+		t.Fatalf("expected \n%s but got \n%s", expected, formatted)
+	}
+}
+
+func TestCSVFormatter(t *testing.T) {
+	w := &testWriter{}
+	csvFormatter := cauldron.NewCSVFormatter(testProject, date(t, "2021-01-01"), date(t, "2021-12-31"), "performance-overview", true, w)
+
+	p := &cauldron.Performance{
+		IssuesTimeOpenAveragePerformanceOverview: cauldron.Float(272.41),
+		OpenIssuesPerformanceOverview:            cauldron.Int(0),
+	}
+
+	err := csvFormatter.Format(p)
+	if err != nil {
+		t.Fatalf("error formatting: %v", err)
+	}
+
+	formatted := string(w.data)
+
+	expected := `project_id,project_name,from,to,tab,metric,value
+1,Test Project,2021-01-01,2021-12-31,performance-overview,issues_time_open_average_performance_overview,272.41
+1,Test Project,2021-01-01,2021-12-31,performance-overview,issues_time_open_median_performance_overview,
+1,Test Project,2021-01-01,2021-12-31,performance-overview,open_issues_performance_overview,0
+1,Test Project,2021-01-01,2021-12-31,performance-overview,reviews_time_open_average_performance_overview,
+1,Test Project,2021-01-01,2021-12-31,performance-overview,reviews_time_open_median_performance_overview,
+1,Test Project,2021-01-01,2021-12-31,performance-overview,open_reviews_performance_overview,
+`
+	if formatted != expected {
+		t.Fatalf("expected \n%s but got \n%s", expected, formatted)
+	}
+}
@@ -0,0 +1,133 @@
+package cauldron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Generic is the fallback for the Cauldron tabs without a dedicated struct.
+// It keeps the fields of the response in the order Cauldron returns them,
+// except the Bokeh charts, and prints the supported ones as metrics.
+type Generic struct {
+	Keys   []string
+	Values map[string]json.RawMessage
+}
+
+func (g *Generic) UnmarshalJSON(bs []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(bs))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object but got %v", tok)
+	}
+
+	g.Keys = []string{}
+	g.Values = map[string]json.RawMessage{}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key := tok.(string)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		if chart(key) {
+			continue
+		}
+
+		if _, ok := g.Values[key]; !ok {
+			g.Keys = append(g.Keys, key)
+		}
+		g.Values[key] = value
+	}
+
+	return nil
+}
+
+func (g Generic) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+
+	for i, k := range g.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(g.Values[k])
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (g *Generic) Data() [][]string {
+	return rows(g.Metrics())
+}
+
+func (g *Generic) Metrics() []Metric {
+	metrics := make([]Metric, 0, len(g.Keys))
+	for _, k := range g.Keys {
+		if !unsupported(k) {
+			metrics = append(metrics, rawMetric(k, MetricName(k), g.Values[k]))
+		}
+	}
+
+	return metrics
+}
+
+// rawMetric converts a JSON value into a metric, supporting numbers and
+// numeric strings. Any other value is considered missing.
+func rawMetric(key string, name string, raw json.RawMessage) Metric {
+	var number json.Number
+	if err := json.Unmarshal(raw, &number); err != nil || number == "" {
+		return Metric{Key: key, Name: name}
+	}
+
+	f, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil {
+		return Metric{Key: key, Name: name}
+	}
+
+	m := Metric{Key: key, Name: name, Value: &f}
+	if strings.ContainsAny(number.String(), ".eE") {
+		m.Precision = 2
+	}
+
+	return m
+}
+
+// MetricName converts a Cauldron JSON key into a human readable name,
+// e.g. "commits_yoy_overview" into "Commits YoY Overview".
+func MetricName(key string) string {
+	words := strings.Split(key, "_")
+	for i, w := range words {
+		switch {
+		case w == "yoy":
+			words[i] = "YoY"
+		case w != "":
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,143 @@
+package cauldron
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/goal"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+// GoalProgress is the progress of a goal of a project by now.
+type GoalProgress struct {
+	ProjectID   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Goal        string `json:"goal"`
+	Key         string `json:"key"`
+	From        string `json:"from"`
+	Deadline    string `json:"deadline"`
+	// Elapsed is the percentage of the period of the goal elapsed by now.
+	Elapsed float64  `json:"elapsed"`
+	Current *float64 `json:"current"`
+	Target  float64  `json:"target"`
+	// Progress is the percentage of the target reached by the current value.
+	Progress *float64 `json:"progress"`
+	// Projected is the value expected at the deadline.
+	Projected *float64 `json:"projected"`
+	Status    string   `json:"status"`
+
+	precision int
+}
+
+// GoalResponse is the JSON document of the progress of the goals.
+type GoalResponse struct {
+	SchemaVersion int            `json:"schema_version"`
+	Goals         []GoalProgress `json:"goals"`
+}
+
+// TrackGoal returns the progress of a goal of a project by now, from the
+// printables fetched for its period up to now, which are not needed for the
+// goals not started yet.
+func TrackGoal(p project.Project, g goal.Goal, window period.Period, now time.Time, printables []Printable) GoalProgress {
+	progress := GoalProgress{
+		ProjectID:   p.ID,
+		ProjectName: p.Name,
+		Goal:        g.Name,
+		Key:         g.Metric,
+		From:        window.From.Format(period.Layout),
+		Deadline:    window.To.Format(period.Layout),
+		Target:      g.Target,
+		Status:      goal.Unknown,
+	}
+
+	if progress.Goal == "" {
+		progress.Goal = MetricName(g.Metric)
+	}
+
+	if now.Before(window.From) {
+		progress.Status = goal.NotStarted
+		return progress
+	}
+
+	elapsed := goal.Elapsed(window, now)
+	progress.Elapsed = elapsed * 100
+
+	var m Metric
+	for _, printable := range printables {
+		for _, candidate := range printable.Metrics() {
+			if candidate.Key == g.Metric {
+				m = candidate
+			}
+		}
+	}
+
+	if g.Name == "" && m.Name != "" {
+		progress.Goal = m.Name
+	}
+
+	progress.precision = m.Precision
+	if m.Value == nil {
+		return progress
+	}
+
+	lower := LowerIsBetter(g.Metric)
+
+	// the counts accumulate over the period, unlike the times and the ratios
+	cumulative := m.Precision == 0 && !lower
+	if g.Cumulative != nil {
+		cumulative = *g.Cumulative
+	}
+
+	projected := goal.Projected(*m.Value, elapsed, cumulative)
+
+	progress.Current = m.Value
+	progress.Progress = Float(goal.Progress(*m.Value, g.Target, lower))
+	progress.Projected = Float(projected)
+	progress.Status = goal.Status(*m.Value, projected, g.Target, elapsed, lower, cumulative)
+
+	return progress
+}
+
+// NewGoalReport renders the progress of the goals as a table, highlighting the
+// goals at risk or missed in the console and markdown formats.
+func NewGoalReport(goals []GoalProgress) Report {
+	table := Table{
+		Title:   "Goals",
+		Headers: []string{"Project", "Goal", "Period", "Elapsed", "Current", "Target", "Progress", "Projected", "Status"},
+	}
+
+	records := Table{
+		Headers: []string{"project_id", "project_name", "goal", "metric", "from", "deadline", "elapsed", "current", "target", "progress", "projected", "status"},
+	}
+
+	for _, g := range goals {
+		elapsed := strconv.FormatFloat(g.Elapsed, 'f', 0, 64)
+		current := Metric{Value: g.Current, Precision: g.precision}.String()
+		target := strconv.FormatFloat(g.Target, 'f', -1, 64)
+		progress := Metric{Value: g.Progress, Precision: 0}.String()
+		projected := Metric{Value: g.Projected, Precision: g.precision}.String()
+
+		percentage := progress
+		if g.Progress != nil {
+			percentage += "%"
+		}
+
+		status := g.Status
+		if status == goal.AtRisk || status == goal.Missed {
+			status = "** " + status + " **"
+		}
+
+		table.Rows = append(table.Rows, []string{g.ProjectName, g.Goal, g.From + " - " + g.Deadline, elapsed + "%", current, target, percentage, projected, status})
+		records.Rows = append(records.Rows, []string{strconv.Itoa(g.ProjectID), g.ProjectName, g.Goal, g.Key, g.From, g.Deadline, elapsed, current, target, progress, projected, g.Status})
+	}
+
+	return Report{
+		Tables: []Table{table},
+		Document: GoalResponse{
+			SchemaVersion: SchemaVersion,
+			Goals:         goals,
+		},
+		Records: &records,
+	}
+}
@@ -0,0 +1,59 @@
+package cauldron_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/goal"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+func TestTrackGoal(t *testing.T) {
+	p := project.Project{ID: 2296, Name: "testcontainers-go"}
+	window := period.Period{
+		From: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC),
+	}
+	now := time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC)
+
+	printables := []cauldron.Printable{
+		&cauldron.Community{OnboardingsGitCommunityOverview: cauldron.Int(4)},
+		&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(1.5)},
+	}
+
+	onboardings := cauldron.TrackGoal(p, goal.Goal{Name: "Onboardings", Metric: "onboardings_git_community_overview", Target: 10}, window, now, printables)
+	if onboardings.Elapsed != 50 || *onboardings.Progress != 40 || *onboardings.Projected != 8 || onboardings.Status != goal.AtRisk {
+		t.Fatalf("unexpected progress of the cumulative goal %+v", onboardings)
+	}
+
+	// the times to close are not projected by the elapsed time
+	closing := cauldron.TrackGoal(p, goal.Goal{Metric: "issues_median_time_to_close_overview", Target: 2}, window, now, printables)
+	if closing.Goal != "Issues Median Time To Close Overview" || *closing.Projected != 1.5 || closing.Status != goal.OnTrack {
+		t.Fatalf("unexpected progress of the time goal %+v", closing)
+	}
+
+	missing := cauldron.TrackGoal(p, goal.Goal{Metric: "commits_activity_overview", Target: 100}, window, now, printables)
+	if missing.Current != nil || missing.Status != goal.Unknown {
+		t.Fatalf("expected an unknown status for a metric not reported but got %+v", missing)
+	}
+
+	future := cauldron.TrackGoal(p, goal.Goal{Metric: "commits_activity_overview", Target: 100}, window, window.From.AddDate(0, 0, -1), nil)
+	if future.Status != goal.NotStarted {
+		t.Fatalf("expected a goal not started but got %+v", future)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := cauldron.NewGoalReport([]cauldron.GoalProgress{onboardings, future}).Write(buf, "csv"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "project_id,project_name,goal,metric,from,deadline,elapsed,current,target,progress,projected,status\n" +
+		"2296,testcontainers-go,Onboardings,onboardings_git_community_overview,2024-04-01,2024-04-30,50,4,10,40,8,at risk\n" +
+		"2296,testcontainers-go,Commits Activity Overview,commits_activity_overview,2024-04-01,2024-04-30,0,,100,,,not started\n"
+	if buf.String() != expected {
+		t.Fatalf("expected\n%s\nbut got\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,137 @@
+package cauldron
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/store"
+)
+
+// HistoryEntry is a stored snapshot of a tab.
+type HistoryEntry struct {
+	ID          int64  `json:"id"`
+	ProjectID   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Tab         string `json:"tab"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	FetchedAt   string `json:"fetched_at"`
+	// Values are the requested metrics, keyed by their JSON key.
+	Values map[string]*float64 `json:"values,omitempty"`
+	// Response is the raw response, only set if no metric was requested.
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// HistoryResponse is the JSON document of the stored snapshots.
+type HistoryResponse struct {
+	SchemaVersion int            `json:"schema_version"`
+	Snapshots     []HistoryEntry `json:"snapshots"`
+}
+
+// NewHistoryReport renders the stored snapshots. If metric keys are given,
+// only the snapshots reporting any of them are listed, with their values.
+func NewHistoryReport(snapshots []store.Snapshot, keys []string) (Report, error) {
+	table := Table{
+		Title:   "History",
+		Headers: []string{"ID", "Project", "Tab", "From", "To", "Fetched at"},
+	}
+
+	records := Table{Headers: []string{"id", "project_id", "project_name", "tab", "from", "to", "fetched_at", "metric", "value"}}
+
+	// the names of the metrics are only known once decoded
+	names := map[string]string{}
+	for _, k := range keys {
+		names[k] = MetricName(k)
+	}
+
+	entries := []HistoryEntry{}
+	rows := [][]string{}
+	metricRows := [][]Metric{}
+
+	for _, s := range snapshots {
+		entry := HistoryEntry{
+			ID:          s.ID,
+			ProjectID:   s.ProjectID,
+			ProjectName: s.ProjectName,
+			Tab:         s.Tab,
+			From:        s.From,
+			To:          s.To,
+			FetchedAt:   s.FetchedAt.Format(time.RFC3339),
+		}
+
+		row := []string{strconv.FormatInt(s.ID, 10), s.ProjectName, s.Tab, s.From, s.To, entry.FetchedAt}
+
+		if len(keys) == 0 {
+			entry.Response = s.Response
+			entries = append(entries, entry)
+			rows = append(rows, row)
+			continue
+		}
+
+		result, err := Decode(s.Tab, s.Response)
+		if err != nil {
+			return Report{}, err
+		}
+
+		found := map[string]Metric{}
+		for _, m := range result.Printable.Metrics() {
+			if _, ok := names[m.Key]; ok {
+				found[m.Key] = m
+				names[m.Key] = m.Name
+			}
+		}
+
+		if len(found) == 0 {
+			continue
+		}
+
+		entry.Values = map[string]*float64{}
+		metrics := make([]Metric, len(keys))
+		for i, k := range keys {
+			m, ok := found[k]
+			if !ok {
+				m = Metric{Key: k}
+			}
+
+			metrics[i] = m
+			if ok {
+				entry.Values[k] = m.Value
+				records.Rows = append(records.Rows, []string{row[0], strconv.Itoa(s.ProjectID), s.ProjectName, s.Tab, s.From, s.To, entry.FetchedAt, k, m.String()})
+			}
+		}
+
+		entries = append(entries, entry)
+		rows = append(rows, row)
+		metricRows = append(metricRows, metrics)
+	}
+
+	for _, k := range keys {
+		table.Headers = append(table.Headers, names[k])
+	}
+
+	for i, row := range rows {
+		if len(keys) > 0 {
+			for _, m := range metricRows[i] {
+				row = append(row, m.String())
+			}
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	report := Report{
+		Tables: []Table{table},
+		Document: HistoryResponse{
+			SchemaVersion: SchemaVersion,
+			Snapshots:     entries,
+		},
+	}
+
+	// without metrics, the records are the rows of the table
+	if len(keys) > 0 {
+		report.Records = &records
+	}
+
+	return report, nil
+}
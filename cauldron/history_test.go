@@ -0,0 +1,66 @@
+package cauldron_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/store"
+)
+
+func TestHistoryReport(t *testing.T) {
+	fetchedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	snapshots := []store.Snapshot{
+		{ID: 1, ProjectID: 1, ProjectName: "go", Tab: "community-overview", From: "2024-01-01", To: "2024-02-01", FetchedAt: fetchedAt, Response: []byte(`{"active_people_git_community_overview":8}`)},
+		{ID: 2, ProjectID: 1, ProjectName: "go", Tab: "performance-overview", From: "2024-01-01", To: "2024-02-01", FetchedAt: fetchedAt, Response: []byte(`{"open_issues_performance_overview":66}`)},
+	}
+
+	t.Run("snapshots", func(tt *testing.T) {
+		report, err := cauldron.NewHistoryReport(snapshots, nil)
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		if len(report.Tables[0].Rows) != 2 {
+			tt.Fatalf("expected 2 rows but got %d", len(report.Tables[0].Rows))
+		}
+
+		buf := &bytes.Buffer{}
+		if err := report.Write(buf, "json"); err != nil {
+			tt.Fatal(err)
+		}
+
+		if !strings.Contains(buf.String(), `"response": {`) {
+			tt.Fatalf("expected the raw responses but got %s", buf.String())
+		}
+	})
+
+	t.Run("metric", func(tt *testing.T) {
+		report, err := cauldron.NewHistoryReport(snapshots, []string{"open_issues_performance_overview"})
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		table := report.Tables[0]
+		if len(table.Rows) != 1 || table.Rows[0][0] != "2" {
+			tt.Fatalf("expected only the snapshot reporting the metric but got %v", table.Rows)
+		}
+
+		if last := table.Rows[0][len(table.Rows[0])-1]; last != "66" {
+			tt.Fatalf("expected the value of the metric but got %s", last)
+		}
+
+		buf := &bytes.Buffer{}
+		if err := report.Write(buf, "csv"); err != nil {
+			tt.Fatal(err)
+		}
+
+		expected := "id,project_id,project_name,tab,from,to,fetched_at,metric,value\n" +
+			"2,1,go,performance-overview,2024-01-01,2024-02-01,2024-03-01T10:00:00Z,open_issues_performance_overview,66\n"
+		if buf.String() != expected {
+			tt.Fatalf("expected\n%s\nbut got\n%s", expected, buf.String())
+		}
+	})
+}
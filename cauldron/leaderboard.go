@@ -0,0 +1,178 @@
+package cauldron
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+// Standing is the position of a project in a leaderboard.
+type Standing struct {
+	// Rank is the position of the project, starting at 1, or 0 if it didn't
+	// report the metric.
+	Rank        int      `json:"rank"`
+	ProjectID   int      `json:"project_id"`
+	ProjectName string   `json:"project_name"`
+	Value       *float64 `json:"value"`
+	// PreviousRank is the position of the project in the previous period, or
+	// 0 if it didn't report the metric.
+	PreviousRank  int      `json:"previous_rank"`
+	PreviousValue *float64 `json:"previous_value"`
+}
+
+// Movement renders the change of rank from the previous period: the positions
+// moved up or down, "=" if the rank didn't change, and "new" if the project
+// wasn't ranked in the previous period.
+func (s Standing) Movement() string {
+	switch {
+	case s.Rank == 0:
+		return NotAvailable
+	case s.PreviousRank == 0:
+		return "new"
+	case s.Rank < s.PreviousRank:
+		return fmt.Sprintf("↑ %d", s.PreviousRank-s.Rank)
+	case s.Rank > s.PreviousRank:
+		return fmt.Sprintf("↓ %d", s.Rank-s.PreviousRank)
+	default:
+		return "="
+	}
+}
+
+// Leaderboard ranks the projects by a metric in a period, comparing their
+// ranks with the ones of the previous period.
+type Leaderboard struct {
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	PreviousFrom string `json:"previous_from"`
+	PreviousTo   string `json:"previous_to"`
+	// Ascending tells if the lowest values rank first.
+	Ascending bool       `json:"ascending"`
+	Standings []Standing `json:"standings"`
+
+	precision int
+}
+
+// LeaderboardResponse is the JSON document of a leaderboard.
+type LeaderboardResponse struct {
+	SchemaVersion int         `json:"schema_version"`
+	Leaderboard   Leaderboard `json:"leaderboard"`
+}
+
+// NewLeaderboard ranks the projects by the metric with the given JSON key in
+// the current printables, one per project, and in the previous ones, keeping
+// the top standings, or all of them if top is 0. The projects not reporting
+// the metric come last, without rank.
+func NewLeaderboard(projects []project.Project, key string, current []Printable, previous []Printable, pd period.Period, ascending bool, top int) Leaderboard {
+	board := Leaderboard{
+		Key:          key,
+		Name:         MetricName(key),
+		From:         pd.From.Format(period.Layout),
+		To:           pd.To.Format(period.Layout),
+		PreviousFrom: pd.Previous().From.Format(period.Layout),
+		PreviousTo:   pd.Previous().To.Format(period.Layout),
+		Ascending:    ascending,
+	}
+
+	values := make([]*float64, len(projects))
+	previousValues := make([]*float64, len(projects))
+	for i := range projects {
+		if m, ok := metricOf(current[i], key); ok {
+			values[i] = m.Value
+			board.Name = m.Name
+			board.precision = m.Precision
+		}
+
+		if m, ok := metricOf(previous[i], key); ok {
+			previousValues[i] = m.Value
+		}
+	}
+
+	ranks := Rank(values, ascending)
+	previousRanks := Rank(previousValues, ascending)
+
+	board.Standings = make([]Standing, len(projects))
+	for i, p := range projects {
+		board.Standings[i] = Standing{
+			Rank:          ranks[i],
+			ProjectID:     p.ID,
+			ProjectName:   p.Name,
+			Value:         values[i],
+			PreviousRank:  previousRanks[i],
+			PreviousValue: previousValues[i],
+		}
+	}
+
+	sort.SliceStable(board.Standings, func(i, j int) bool {
+		a, b := board.Standings[i].Rank, board.Standings[j].Rank
+		return a != 0 && (b == 0 || a < b)
+	})
+
+	if top > 0 && len(board.Standings) > top {
+		board.Standings = board.Standings[:top]
+	}
+
+	return board
+}
+
+// metricOf returns the metric with the given JSON key of a printable.
+func metricOf(printable Printable, key string) (Metric, bool) {
+	if printable == nil {
+		return Metric{}, false
+	}
+
+	for _, m := range printable.Metrics() {
+		if m.Key == key {
+			return m, true
+		}
+	}
+
+	return Metric{}, false
+}
+
+// NewLeaderboardReport renders a leaderboard as a table with the rank, the
+// value and the change of rank of each project.
+func NewLeaderboardReport(board Leaderboard) Report {
+	order := "highest first"
+	if board.Ascending {
+		order = "lowest first"
+	}
+
+	table := Table{
+		Title:   fmt.Sprintf("Leaderboard: %s, %s - %s (%s)", board.Name, board.From, board.To, order),
+		Headers: []string{"Rank", "Project", "Value", "Previous rank", "Previous value", "Change"},
+	}
+
+	records := Table{
+		Headers: []string{"rank", "project_id", "project_name", "metric", "from", "to", "value", "previous_rank", "previous_value", "change"},
+	}
+
+	for _, s := range board.Standings {
+		rank, previousRank := NotAvailable, NotAvailable
+		if s.Rank > 0 {
+			rank = strconv.Itoa(s.Rank)
+		}
+		if s.PreviousRank > 0 {
+			previousRank = strconv.Itoa(s.PreviousRank)
+		}
+
+		value := Metric{Value: s.Value, Precision: board.precision}.String()
+		previousValue := Metric{Value: s.PreviousValue, Precision: board.precision}.String()
+
+		table.Rows = append(table.Rows, []string{rank, s.ProjectName, value, previousRank, previousValue, s.Movement()})
+		records.Rows = append(records.Rows, []string{rank, strconv.Itoa(s.ProjectID), s.ProjectName, board.Key, board.From, board.To, value, previousRank, previousValue, s.Movement()})
+	}
+
+	return Report{
+		Tables: []Table{table},
+		Document: LeaderboardResponse{
+			SchemaVersion: SchemaVersion,
+			Leaderboard:   board,
+		},
+		Records: &records,
+	}
+}
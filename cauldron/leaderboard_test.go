@@ -0,0 +1,87 @@
+package cauldron_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+func TestLeaderboard(t *testing.T) {
+	projects := []project.Project{
+		{ID: 1, Name: "go"},
+		{ID: 2, Name: "java"},
+		{ID: 3, Name: "python"},
+		{ID: 4, Name: "rust"},
+	}
+
+	pd := period.Period{
+		From: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	current := []cauldron.Printable{
+		&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(10)},
+		&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(5)},
+		&cauldron.Overview{},
+		&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(20)},
+	}
+
+	previous := []cauldron.Printable{
+		&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(4)},
+		&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(8)},
+		&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(1)},
+		&cauldron.Overview{},
+	}
+
+	testCases := []struct {
+		name      string
+		ascending bool
+		top       int
+		expected  string
+	}{
+		{
+			name:      "ascending",
+			ascending: true,
+			top:       0,
+			expected: "rank,project_id,project_name,metric,from,to,value,previous_rank,previous_value,change\n" +
+				"1,2,java,issues_median_time_to_close_overview,2024-04-01,2024-06-30,5.00,3,8.00,↑ 2\n" +
+				"2,1,go,issues_median_time_to_close_overview,2024-04-01,2024-06-30,10.00,2,4.00,=\n" +
+				"3,4,rust,issues_median_time_to_close_overview,2024-04-01,2024-06-30,20.00,,,new\n" +
+				",3,python,issues_median_time_to_close_overview,2024-04-01,2024-06-30,,1,1.00,\n",
+		},
+		{
+			name:      "descending top",
+			ascending: false,
+			top:       3,
+			expected: "rank,project_id,project_name,metric,from,to,value,previous_rank,previous_value,change\n" +
+				"1,4,rust,issues_median_time_to_close_overview,2024-04-01,2024-06-30,20.00,,,new\n" +
+				"2,1,go,issues_median_time_to_close_overview,2024-04-01,2024-06-30,10.00,2,4.00,=\n" +
+				"3,2,java,issues_median_time_to_close_overview,2024-04-01,2024-06-30,5.00,1,8.00,↓ 2\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			board := cauldron.NewLeaderboard(projects, "issues_median_time_to_close_overview", current, previous, pd, testCase.ascending, testCase.top)
+			if board.PreviousFrom != "2024-01-01" || board.PreviousTo != "2024-03-31" {
+				tt.Fatalf("unexpected previous period %s - %s", board.PreviousFrom, board.PreviousTo)
+			}
+
+			buf := &bytes.Buffer{}
+			if err := cauldron.NewLeaderboardReport(board).Write(buf, "csv"); err != nil {
+				tt.Fatal(err)
+			}
+
+			if buf.String() != testCase.expected {
+				tt.Fatalf("expected\n%s\nbut got\n%s", testCase.expected, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package cauldron
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+// MatrixRow is a metric of a tab across several projects.
+type MatrixRow struct {
+	Key    string     `json:"key"`
+	Name   string     `json:"name"`
+	Values []*float64 `json:"values"`
+	// Ranks are the positions of the projects for the metric, according to
+	// its direction. Only set if ranking was requested.
+	Ranks []int `json:"ranks,omitempty"`
+
+	precision int
+}
+
+// MatrixResponse is the JSON document of a tab across several projects.
+type MatrixResponse struct {
+	SchemaVersion int               `json:"schema_version"`
+	From          string            `json:"from"`
+	To            string            `json:"to"`
+	Tab           string            `json:"tab"`
+	Projects      []project.Project `json:"projects"`
+	Rows          []MatrixRow       `json:"rows"`
+}
+
+// NewMatrix builds one row per metric from the printables of a tab, each one
+// fetched for the project at the same index.
+func NewMatrix(printables []Printable, rank bool) []MatrixRow {
+	rows := []MatrixRow{}
+	index := map[string]int{}
+
+	for i, p := range printables {
+		for _, m := range p.Metrics() {
+			idx, ok := index[m.Key]
+			if !ok {
+				idx = len(rows)
+				index[m.Key] = idx
+				rows = append(rows, MatrixRow{Key: m.Key, Name: m.Name, Values: make([]*float64, len(printables)), precision: m.Precision})
+			}
+
+			rows[idx].Values[i] = m.Value
+		}
+	}
+
+	if rank {
+		for i := range rows {
+			rows[i].Ranks = Rank(rows[i].Values, LowerIsBetter(rows[i].Key))
+		}
+	}
+
+	return rows
+}
+
+// Cells renders the values of the row, followed by their rank if set.
+func (r MatrixRow) Cells() []string {
+	cells := r.ValueCells()
+	for i := range cells {
+		if len(r.Ranks) > i && r.Ranks[i] > 0 {
+			cells[i] += fmt.Sprintf(" (#%d)", r.Ranks[i])
+		}
+	}
+
+	return cells
+}
+
+// breachCells renders the cells of the row, flagging the values worse than
+// the thresholds of the project at the same index.
+func (r MatrixRow) breachCells(projects []project.Project) []string {
+	cells := r.Cells()
+	for i := range cells {
+		if i < len(projects) {
+			cells[i] += breach(projects[i].Thresholds, r.Key, r.Values[i])
+		}
+	}
+
+	return cells
+}
+
+// ValueCells renders the values of the row, without their rank, so they stay
+// numeric in the csv format.
+func (r MatrixRow) ValueCells() []string {
+	cells := make([]string, len(r.Values))
+	for i, v := range r.Values {
+		cells[i] = Metric{Value: v, Precision: r.precision}.String()
+	}
+
+	return cells
+}
+
+// RankCells renders the ranks of the row, or nil if it's not ranked.
+func (r MatrixRow) RankCells() []string {
+	if r.Ranks == nil {
+		return nil
+	}
+
+	cells := make([]string, len(r.Ranks))
+	for i, rank := range r.Ranks {
+		cells[i] = NotAvailable
+		if rank > 0 {
+			cells[i] = strconv.Itoa(rank)
+		}
+	}
+
+	return cells
+}
+
+// rankHeaders returns the headers of the rank columns of the projects in the
+// csv format, or nil if the rows are not ranked.
+func rankHeaders(projects []project.Project, rows []MatrixRow) []string {
+	if len(rows) == 0 || rows[0].Ranks == nil {
+		return nil
+	}
+
+	headers := make([]string, len(projects))
+	for i, p := range projects {
+		headers[i] = p.Name + " rank"
+	}
+
+	return headers
+}
+
+// NewMatrixReport renders a tab with the metrics as rows and the projects as
+// columns. In the csv format, the tab is the first column of the records, so
+// the records of several tabs can be merged, and the ranks are separate columns.
+func NewMatrixReport(projects []project.Project, pd period.Period, tab string, rows []MatrixRow) Report {
+	table := Table{
+		Title:   fmt.Sprintf("Tab: %s, From: %s, To: %s", tab, pd.From.Format(period.Layout), pd.To.Format(period.Layout)),
+		Headers: []string{"Metric"},
+	}
+
+	records := Table{Headers: []string{"tab", "metric"}}
+
+	for _, p := range projects {
+		table.Headers = append(table.Headers, p.Name)
+		records.Headers = append(records.Headers, p.Name)
+	}
+
+	records.Headers = append(records.Headers, rankHeaders(projects, rows)...)
+
+	for _, r := range rows {
+		table.Rows = append(table.Rows, append([]string{r.Name}, r.breachCells(projects)...))
+
+		record := append([]string{tab, r.Key}, r.ValueCells()...)
+		records.Rows = append(records.Rows, append(record, r.RankCells()...))
+	}
+
+	return Report{
+		Tables: []Table{table},
+		Document: MatrixResponse{
+			SchemaVersion: SchemaVersion,
+			From:          pd.From.Format(period.Layout),
+			To:            pd.To.Format(period.Layout),
+			Tab:           tab,
+			Projects:      projects,
+			Rows:          rows,
+		},
+		Records: &records,
+	}
+}
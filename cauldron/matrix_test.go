@@ -0,0 +1,149 @@
+package cauldron_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+func TestRank(t *testing.T) {
+	values := []*float64{cauldron.Float(3), nil, cauldron.Float(7), cauldron.Float(3), cauldron.Float(1)}
+
+	t.Run("higher-is-better", func(tt *testing.T) {
+		expected := []int{2, 0, 1, 2, 4}
+		if ranks := cauldron.Rank(values, false); !reflect.DeepEqual(ranks, expected) {
+			tt.Fatalf("expected %v but got %v", expected, ranks)
+		}
+	})
+
+	t.Run("lower-is-better", func(tt *testing.T) {
+		expected := []int{2, 0, 4, 2, 1}
+		if ranks := cauldron.Rank(values, true); !reflect.DeepEqual(ranks, expected) {
+			tt.Fatalf("expected %v but got %v", expected, ranks)
+		}
+	})
+}
+
+func TestMatrixReport(t *testing.T) {
+	projects := []project.Project{{ID: 1, Name: "go"}, {ID: 2, Name: "java"}}
+	pd := period.Period{From: date(t, "2024-01-01"), To: date(t, "2024-03-31")}
+
+	printables := []cauldron.Printable{
+		&cauldron.Performance{OpenIssuesPerformanceOverview: cauldron.Int(66), ReviewsTimeOpenMedianPerformanceOverview: cauldron.Float(47.8)},
+		&cauldron.Performance{OpenIssuesPerformanceOverview: cauldron.Int(120)},
+	}
+
+	rows := cauldron.NewMatrix(printables, true)
+	report := cauldron.NewMatrixReport(projects, pd, "performance-overview", rows[2:5])
+
+	t.Run("console", func(tt *testing.T) {
+		w := &testWriter{}
+		if err := report.Write(w, "console"); err != nil {
+			tt.Fatal(err)
+		}
+
+		expected := `Tab: performance-overview, From: 2024-01-01, To: 2024-03-31
++------------------------------------------------+------------+----------+
+|                     METRIC                     |     GO     |   JAVA   |
++------------------------------------------------+------------+----------+
+| Open Issues Performance Overview               |    66 (#1) | 120 (#2) |
+| Reviews Time Open Average Performance Overview |        n/a |      n/a |
+| Reviews Time Open Median Performance Overview  | 47.80 (#1) |      n/a |
++------------------------------------------------+------------+----------+
+
+`
+		if string(w.data) != expected {
+			tt.Fatalf("expected \n%s but got \n%s", expected, string(w.data))
+		}
+	})
+
+	t.Run("csv", func(tt *testing.T) {
+		w := &testWriter{}
+		if err := report.Write(w, "csv"); err != nil {
+			tt.Fatal(err)
+		}
+
+		expected := `tab,metric,go,java,go rank,java rank
+performance-overview,open_issues_performance_overview,66,120,1,2
+performance-overview,reviews_time_open_average_performance_overview,,,,
+performance-overview,reviews_time_open_median_performance_overview,47.80,,1,
+`
+		if string(w.data) != expected {
+			tt.Fatalf("expected \n%s but got \n%s", expected, string(w.data))
+		}
+	})
+
+	t.Run("html", func(tt *testing.T) {
+		w := &testWriter{}
+		if err := report.Write(w, "html"); err != nil {
+			tt.Fatal(err)
+		}
+
+		expected := `<h2>Tab: performance-overview, From: 2024-01-01, To: 2024-03-31</h2>
+<table>
+  <thead>
+    <tr><th>Metric</th><th>go</th><th>java</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>Open Issues Performance Overview</td><td style="text-align: right">66 (#1)</td><td style="text-align: right">120 (#2)</td></tr>
+    <tr><td>Reviews Time Open Average Performance Overview</td><td style="text-align: right">n/a</td><td style="text-align: right">n/a</td></tr>
+    <tr><td>Reviews Time Open Median Performance Overview</td><td style="text-align: right">47.80 (#1)</td><td style="text-align: right">n/a</td></tr>
+  </tbody>
+</table>
+`
+		if string(w.data) != expected {
+			tt.Fatalf("expected \n%s but got \n%s", expected, string(w.data))
+		}
+	})
+}
+
+func TestMatrixReportThresholds(t *testing.T) {
+	projects := []project.Project{
+		{ID: 1, Name: "go", Thresholds: map[string]float64{"open_issues_performance_overview": 100}},
+		{ID: 2, Name: "java", Thresholds: map[string]float64{"open_issues_performance_overview": 100}},
+	}
+	pd := period.Period{From: date(t, "2024-01-01"), To: date(t, "2024-03-31")}
+
+	printables := []cauldron.Printable{
+		&cauldron.Performance{OpenIssuesPerformanceOverview: cauldron.Int(66)},
+		&cauldron.Performance{OpenIssuesPerformanceOverview: cauldron.Int(120)},
+	}
+
+	report := cauldron.NewMatrixReport(projects, pd, "performance-overview", cauldron.NewMatrix(printables, false)[2:3])
+
+	expected := []string{"Open Issues Performance Overview", "66", "120" + cauldron.BreachMarker}
+	if !reflect.DeepEqual(report.Tables[0].Rows[0], expected) {
+		t.Fatalf("expected %v but got %v", expected, report.Tables[0].Rows[0])
+	}
+
+	// the csv records stay numeric
+	expected = []string{"performance-overview", "open_issues_performance_overview", "66", "120"}
+	if !reflect.DeepEqual(report.Records.Rows[0], expected) {
+		t.Fatalf("expected %v but got %v", expected, report.Records.Rows[0])
+	}
+}
+
+func TestReportCSVHeadersOnce(t *testing.T) {
+	table := cauldron.Table{Headers: []string{"metric", "value"}, Rows: [][]string{{"a", "1"}}}
+	other := cauldron.Table{Headers: []string{"metric", "rank"}, Rows: [][]string{{"b", "2"}}}
+
+	report := cauldron.Report{Tables: []cauldron.Table{table, table, other}}
+
+	w := &testWriter{}
+	if err := report.Write(w, "csv"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `metric,value
+a,1
+a,1
+metric,rank
+b,2
+`
+	if string(w.data) != expected {
+		t.Fatalf("expected \n%s but got \n%s", expected, string(w.data))
+	}
+}
@@ -0,0 +1,99 @@
+package cauldron
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NotAvailable is the text rendered for a metric that Cauldron did not report.
+const NotAvailable = "n/a"
+
+// Metric is a single named value of a tab. A nil Value means Cauldron did not
+// report the metric, which is different from reporting a zero.
+type Metric struct {
+	Key       string   // the JSON key in the Cauldron response
+	Name      string   // the human readable name
+	Value     *float64 // nil when the metric is missing or null
+	Precision int      // the number of decimals used to render the value
+}
+
+// String renders the value of the metric, or NotAvailable if it is missing.
+func (m Metric) String() string {
+	if m.Value == nil {
+		return NotAvailable
+	}
+
+	return strconv.FormatFloat(*m.Value, 'f', m.Precision, 64)
+}
+
+// Int returns a pointer to the given int, to build nullable metric values.
+func Int(v int) *int {
+	return &v
+}
+
+// Float returns a pointer to the given float64, to build nullable metric values.
+func Float(v float64) *float64 {
+	return &v
+}
+
+// String returns a pointer to the given string, to build nullable metric values.
+func String(v string) *string {
+	return &v
+}
+
+func intMetric(key string, name string, v *int) Metric {
+	m := Metric{Key: key, Name: name}
+	if v != nil {
+		f := float64(*v)
+		m.Value = &f
+	}
+
+	return m
+}
+
+func floatMetric(key string, name string, v *float64) Metric {
+	return Metric{Key: key, Name: name, Value: v, Precision: 2}
+}
+
+// stringMetric converts the numeric strings Cauldron returns for some
+// metrics, e.g. "163.13", treating unparseable values as missing, which
+// Decode reports as invalid fields.
+func stringMetric(key string, name string, v *string) Metric {
+	m := Metric{Key: key, Name: name, Precision: 2}
+	if v == nil {
+		return m
+	}
+
+	f, err := strconv.ParseFloat(*v, 64)
+	if err == nil {
+		m.Value = &f
+	}
+
+	return m
+}
+
+// rows converts the metrics into the name/value rows of the Printable interface.
+func rows(metrics []Metric) [][]string {
+	data := make([][]string, 0, len(metrics))
+	for _, m := range metrics {
+		data = append(data, []string{m.Name, m.String()})
+	}
+
+	return data
+}
+
+// lowerIsBetterTokens identify the metrics that improve when they decrease.
+var lowerIsBetterTokens = []string{"time_to_close", "time_open", "open_issues", "open_reviews", "issues_open", "reviews_open", "open_backlog"}
+
+// LowerIsBetter returns true for the metrics that improve when they decrease,
+// like the times to close or the number of open issues and reviews. It is
+// based on the naming of the keys, so it also applies to generic tabs.
+func LowerIsBetter(key string) bool {
+	for _, token := range lowerIsBetterTokens {
+		if strings.Contains(key, token) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,166 @@
+package cauldron
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+// Mover is the change of a metric of a tab of a project between two periods.
+type Mover struct {
+	ProjectID   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Tab         string `json:"tab"`
+	Comparison
+}
+
+// Movers are the largest changes of the metrics of the projects between two
+// periods, A and B.
+type Movers struct {
+	FromA string `json:"from_a"`
+	ToA   string `json:"to_a"`
+	FromB string `json:"from_b"`
+	ToB   string `json:"to_b"`
+	// MinBase is the minimum absolute value in A of the relative changes, as
+	// the changes from very small values are noise.
+	MinBase           float64 `json:"min_base"`
+	RelativeIncreases []Mover `json:"relative_increases"`
+	RelativeDecreases []Mover `json:"relative_decreases"`
+	AbsoluteIncreases []Mover `json:"absolute_increases"`
+	AbsoluteDecreases []Mover `json:"absolute_decreases"`
+}
+
+// MoversResponse is the JSON document of the top movers.
+type MoversResponse struct {
+	SchemaVersion int    `json:"schema_version"`
+	Movers        Movers `json:"movers"`
+}
+
+// CompareProject matches the metrics of the tabs of a project fetched for two
+// periods, in the same order as the tabs.
+func CompareProject(p project.Project, tabs []string, a []Printable, b []Printable) []Mover {
+	movers := []Mover{}
+	for i, tab := range tabs {
+		for _, c := range Compare(a[i], b[i]) {
+			movers = append(movers, Mover{ProjectID: p.ID, ProjectName: p.Name, Tab: tab, Comparison: c})
+		}
+	}
+
+	return movers
+}
+
+// NewMovers selects the top largest relative and absolute increases and
+// decreases of the compared metrics, skipping the relative changes from a
+// value in A smaller than the minimum base, in absolute terms.
+func NewMovers(compared []Mover, a period.Period, b period.Period, top int, minBase float64) Movers {
+	movers := Movers{
+		FromA:             a.From.Format(period.Layout),
+		ToA:               a.To.Format(period.Layout),
+		FromB:             b.From.Format(period.Layout),
+		ToB:               b.To.Format(period.Layout),
+		MinBase:           minBase,
+		RelativeIncreases: []Mover{},
+		RelativeDecreases: []Mover{},
+		AbsoluteIncreases: []Mover{},
+		AbsoluteDecreases: []Mover{},
+	}
+
+	for _, m := range compared {
+		if m.Delta == nil || *m.Delta == 0 {
+			continue
+		}
+
+		if *m.Delta > 0 {
+			movers.AbsoluteIncreases = append(movers.AbsoluteIncreases, m)
+		} else {
+			movers.AbsoluteDecreases = append(movers.AbsoluteDecreases, m)
+		}
+
+		if m.Change == nil || math.Abs(*m.A) < minBase {
+			continue
+		}
+
+		if *m.Change > 0 {
+			movers.RelativeIncreases = append(movers.RelativeIncreases, m)
+		} else {
+			movers.RelativeDecreases = append(movers.RelativeDecreases, m)
+		}
+	}
+
+	movers.RelativeIncreases = largest(movers.RelativeIncreases, top, func(m Mover) float64 { return *m.Change })
+	movers.RelativeDecreases = largest(movers.RelativeDecreases, top, func(m Mover) float64 { return -*m.Change })
+	movers.AbsoluteIncreases = largest(movers.AbsoluteIncreases, top, func(m Mover) float64 { return *m.Delta })
+	movers.AbsoluteDecreases = largest(movers.AbsoluteDecreases, top, func(m Mover) float64 { return -*m.Delta })
+
+	return movers
+}
+
+// largest sorts the movers by the given size, from the largest, keeping the
+// top ones.
+func largest(movers []Mover, top int, size func(Mover) float64) []Mover {
+	sort.SliceStable(movers, func(i, j int) bool {
+		return size(movers[i]) > size(movers[j])
+	})
+
+	if top > 0 && len(movers) > top {
+		return movers[:top]
+	}
+
+	return movers
+}
+
+// NewMoversReport renders the top movers as a table per kind of change,
+// highlighting the regressions in the console and markdown formats, and as
+// one record per mover in the csv format.
+func NewMoversReport(movers Movers) Report {
+	kinds := []struct {
+		title  string
+		kind   string
+		movers []Mover
+	}{
+		{title: "Top relative increases", kind: "relative_increase", movers: movers.RelativeIncreases},
+		{title: "Top relative decreases", kind: "relative_decrease", movers: movers.RelativeDecreases},
+		{title: "Top absolute increases", kind: "absolute_increase", movers: movers.AbsoluteIncreases},
+		{title: "Top absolute decreases", kind: "absolute_decrease", movers: movers.AbsoluteDecreases},
+	}
+
+	tables := []Table{}
+
+	records := Table{
+		Headers: []string{"kind", "project_id", "project_name", "tab", "metric", "a", "b", "delta", "change", "status"},
+	}
+
+	for _, k := range kinds {
+		table := Table{
+			Title:   fmt.Sprintf("%s, A: %s..%s, B: %s..%s", k.title, movers.FromA, movers.ToA, movers.FromB, movers.ToB),
+			Headers: []string{"Project", "Tab", "Metric", "A", "B", "Delta", "Change", "Status"},
+		}
+
+		for _, m := range k.movers {
+			cells := m.Cells()
+			table.Rows = append(table.Rows, append([]string{m.ProjectName, m.Tab, m.Name}, cells...))
+
+			change := NotAvailable
+			if m.Change != nil {
+				change = strconv.FormatFloat(*m.Change, 'f', 2, 64)
+			}
+
+			records.Rows = append(records.Rows, []string{k.kind, strconv.Itoa(m.ProjectID), m.ProjectName, m.Tab, m.Key, cells[0], cells[1], cells[2], change, m.Status})
+		}
+
+		tables = append(tables, table)
+	}
+
+	return Report{
+		Tables: tables,
+		Document: MoversResponse{
+			SchemaVersion: SchemaVersion,
+			Movers:        movers,
+		},
+		Records: &records,
+	}
+}
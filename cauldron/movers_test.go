@@ -0,0 +1,62 @@
+package cauldron_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+func TestMovers(t *testing.T) {
+	tabs := []string{"activity-overview", "overview"}
+
+	a := period.Period{From: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)}
+	b := period.Period{From: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)}
+
+	compared := cauldron.CompareProject(project.Project{ID: 1, Name: "go"}, tabs,
+		[]cauldron.Printable{
+			&cauldron.Activity{CommitsActivityOverview: cauldron.Int(100), IssuesCreatedActivityOverview: cauldron.Int(2), IssuesClosedActivityOverview: cauldron.Int(40)},
+			&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(20)},
+		},
+		[]cauldron.Printable{
+			&cauldron.Activity{CommitsActivityOverview: cauldron.Int(150), IssuesCreatedActivityOverview: cauldron.Int(10), IssuesClosedActivityOverview: cauldron.Int(30)},
+			&cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(32)},
+		},
+	)
+	compared = append(compared, cauldron.CompareProject(project.Project{ID: 2, Name: "java"}, tabs[:1],
+		[]cauldron.Printable{&cauldron.Activity{CommitsActivityOverview: cauldron.Int(500)}},
+		[]cauldron.Printable{&cauldron.Activity{CommitsActivityOverview: cauldron.Int(300)}},
+	)...)
+
+	movers := cauldron.NewMovers(compared, a, b, 2, 10)
+
+	// the issues created grew by 400% from a base under 10, which is noise
+	if len(movers.RelativeIncreases) != 2 || movers.RelativeIncreases[0].Key != "issues_median_time_to_close_overview" || movers.RelativeIncreases[1].Key != "commits_activity_overview" {
+		t.Fatalf("unexpected relative increases %+v", movers.RelativeIncreases)
+	}
+
+	if len(movers.AbsoluteIncreases) != 2 || movers.AbsoluteIncreases[0].Key != "commits_activity_overview" || movers.AbsoluteIncreases[1].Key != "issues_median_time_to_close_overview" {
+		t.Fatalf("unexpected absolute increases %+v", movers.AbsoluteIncreases)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := cauldron.NewMoversReport(movers).Write(buf, "csv"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "kind,project_id,project_name,tab,metric,a,b,delta,change,status\n" +
+		"relative_increase,1,go,overview,issues_median_time_to_close_overview,20.00,32.00,+12.00,60.00,worse\n" +
+		"relative_increase,1,go,activity-overview,commits_activity_overview,100,150,+50,50.00,better\n" +
+		"relative_decrease,2,java,activity-overview,commits_activity_overview,500,300,-200,-40.00,worse\n" +
+		"relative_decrease,1,go,activity-overview,issues_closed_activity_overview,40,30,-10,-25.00,worse\n" +
+		"absolute_increase,1,go,activity-overview,commits_activity_overview,100,150,+50,50.00,better\n" +
+		"absolute_increase,1,go,overview,issues_median_time_to_close_overview,20.00,32.00,+12.00,60.00,worse\n" +
+		"absolute_decrease,2,java,activity-overview,commits_activity_overview,500,300,-200,-40.00,worse\n" +
+		"absolute_decrease,1,go,activity-overview,issues_closed_activity_overview,40,30,-10,-25.00,worse\n"
+	if buf.String() != expected {
+		t.Fatalf("expected\n%s\nbut got\n%s", expected, buf.String())
+	}
+}
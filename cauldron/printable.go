@@ -2,4 +2,6 @@ package cauldron
 
 type Printable interface {
 	Data() [][]string
+	// Metrics returns the values of the tab, in the order they are displayed.
+	Metrics() []Metric
 }
@@ -0,0 +1,37 @@
+package cauldron
+
+import (
+	"sort"
+)
+
+// Rank returns the rank of each value, starting at 1 for the best one. Ties
+// share the same rank, and missing values have rank 0.
+func Rank(values []*float64, lowerIsBetter bool) []int {
+	indexes := []int{}
+	for i, v := range values {
+		if v != nil {
+			indexes = append(indexes, i)
+		}
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := *values[indexes[i]], *values[indexes[j]]
+		if lowerIsBetter {
+			return a < b
+		}
+
+		return a > b
+	})
+
+	ranks := make([]int, len(values))
+	for i, idx := range indexes {
+		if i > 0 && *values[idx] == *values[indexes[i-1]] {
+			ranks[idx] = ranks[indexes[i-1]]
+			continue
+		}
+
+		ranks[idx] = i + 1
+	}
+
+	return ranks
+}
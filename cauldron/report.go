@@ -0,0 +1,205 @@
+package cauldron
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Table is a titled grid of cells. The first column is left aligned, and the
+// rest of them, usually holding values, are right aligned.
+type Table struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+}
+
+// Report is the output of the commands that don't print a single tab
+// response. It is rendered as tables, except in the json format, which
+// marshals the Document.
+type Report struct {
+	Tables   []Table
+	Document any
+	// Records is the table written in the csv format, when the tables
+	// are not suitable for it. If nil, the tables are written instead.
+	Records *Table
+}
+
+// Write renders the report in the given format: console, markdown, csv, html or json.
+func (r Report) Write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		bs, err := json.MarshalIndent(r.Document, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshalling JSON: %w", err)
+		}
+
+		_, err = w.Write(append(bs, '\n'))
+		return err
+	case "csv":
+		tables := r.Tables
+		if r.Records != nil {
+			tables = []Table{*r.Records}
+		}
+
+		var headers []string
+		for _, t := range tables {
+			// the tables sharing the headers are written as a single table
+			if err := t.writeCSV(w, !slices.Equal(t.Headers, headers)); err != nil {
+				return err
+			}
+			headers = t.Headers
+		}
+	case "markdown":
+		for _, t := range r.Tables {
+			t.writeMarkdown(w)
+		}
+	case "html":
+		for _, t := range r.Tables {
+			t.writeHTML(w)
+		}
+	default:
+		for _, t := range r.Tables {
+			t.writeConsole(w)
+		}
+	}
+
+	return nil
+}
+
+// MergeReports joins the reports into one: their tables are concatenated,
+// their records share the headers of the first one, and their documents
+// are marshalled as an array.
+func MergeReports(reports ...Report) Report {
+	merged := Report{}
+	documents := []any{}
+
+	for _, r := range reports {
+		merged.Tables = append(merged.Tables, r.Tables...)
+		documents = append(documents, r.Document)
+
+		if r.Records == nil {
+			continue
+		}
+
+		if merged.Records == nil {
+			merged.Records = &Table{Headers: r.Records.Headers}
+		}
+		merged.Records.Rows = append(merged.Records.Rows, r.Records.Rows...)
+	}
+
+	merged.Document = documents
+	return merged
+}
+
+func (t Table) alignment() []int {
+	alignment := make([]int, len(t.Headers))
+	for i := range alignment {
+		alignment[i] = tablewriter.ALIGN_RIGHT
+	}
+
+	if len(alignment) > 0 {
+		alignment[0] = tablewriter.ALIGN_LEFT
+	}
+
+	return alignment
+}
+
+func (t Table) writeConsole(w io.Writer) {
+	if t.Title != "" {
+		fmt.Fprintln(w, t.Title)
+	}
+
+	table := tablewriter.NewWriter(w)
+
+	table.SetHeader(t.Headers)
+	table.SetAutoWrapText(false)
+	table.SetColumnAlignment(t.alignment())
+	table.AppendBulk(t.Rows)
+
+	table.Render()
+	fmt.Fprintln(w)
+}
+
+func (t Table) writeMarkdown(w io.Writer) {
+	if t.Title != "" {
+		fmt.Fprintf(w, "## %s\n\n", t.Title)
+	}
+
+	table := tablewriter.NewWriter(w)
+
+	table.SetHeader(t.Headers)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetColumnAlignment(t.alignment())
+	table.AppendBulk(t.Rows)
+
+	table.Render()
+	fmt.Fprintln(w)
+}
+
+// writeCSV writes the rows, preceded by the headers if requested, leaving the
+// missing values empty.
+func (t Table) writeCSV(w io.Writer, headers bool) error {
+	cw := csv.NewWriter(w)
+
+	if headers {
+		if err := cw.Write(t.Headers); err != nil {
+			return fmt.Errorf("error writing CSV: %w", err)
+		}
+	}
+
+	for _, row := range t.Rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			if cell != NotAvailable {
+				record[i] = cell
+			}
+		}
+
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("error writing CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (t Table) writeHTML(w io.Writer) {
+	if t.Title != "" {
+		fmt.Fprintf(w, "<h2>%s</h2>\n", html.EscapeString(t.Title))
+	}
+
+	fmt.Fprintln(w, "<table>")
+
+	fmt.Fprint(w, "  <thead>\n    <tr>")
+	for _, h := range t.Headers {
+		fmt.Fprintf(w, "<th>%s</th>", html.EscapeString(h))
+	}
+	fmt.Fprint(w, "</tr>\n  </thead>\n")
+
+	fmt.Fprintln(w, "  <tbody>")
+	for _, row := range t.Rows {
+		fmt.Fprint(w, "    <tr>")
+		for i, cell := range row {
+			if i == 0 {
+				fmt.Fprintf(w, "<td>%s</td>", html.EscapeString(cell))
+				continue
+			}
+
+			fmt.Fprintf(w, "<td style=\"text-align: right\">%s</td>", html.EscapeString(cell))
+		}
+		fmt.Fprintln(w, "</tr>")
+	}
+	fmt.Fprintln(w, "  </tbody>")
+
+	fmt.Fprintln(w, "</table>")
+}
@@ -0,0 +1,202 @@
+package cauldron
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+// Aggregation is the way the values of a metric are rolled up across projects.
+type Aggregation string
+
+const (
+	// Sum adds the values, which is right for counts
+	Sum Aggregation = "sum"
+	// People adds the counts of distinct people, as an approximation: the
+	// people contributing to several projects are counted more than once
+	People Aggregation = "people"
+	// Mean averages the values, as an approximation for the medians, the
+	// averages and the ratios, which can't be recomputed without the raw data
+	Mean Aggregation = "mean"
+	// YearOverYear recomputes the year-over-year percentages from the rolled
+	// up values of the last year and of the year before, which is derived from
+	// the percentage of each project
+	YearOverYear Aggregation = "yoy"
+)
+
+// ApproximatePrefix flags the rolled up values that are approximations.
+const ApproximatePrefix = "~"
+
+// AggregationOf returns the aggregation of a metric, based on the naming of its key.
+func AggregationOf(key string) Aggregation {
+	switch {
+	case strings.Contains(key, "_yoy_"):
+		return YearOverYear
+	case strings.Contains(key, "median"), strings.Contains(key, "average"), strings.HasPrefix(key, "lines_commit"):
+		return Mean
+	case strings.HasPrefix(key, "active_people_"), strings.HasPrefix(key, "onboardings_"),
+		strings.HasPrefix(key, "commit_authors_"), strings.Contains(key, "_submitters_"):
+		return People
+	default:
+		return Sum
+	}
+}
+
+// Approximate reports whether the rolled up values are approximations.
+func (a Aggregation) Approximate() bool {
+	return a == Mean || a == People
+}
+
+// Aggregate rolls up the values of a metric, ignoring the missing ones. The
+// year-over-year percentages can't be rolled up on their own, see AggregateYoY.
+func Aggregate(key string, values []*float64) *float64 {
+	aggregation := AggregationOf(key)
+	if aggregation == YearOverYear {
+		return nil
+	}
+
+	total, n := 0.0, 0
+	for _, v := range values {
+		if v != nil {
+			total += *v
+			n++
+		}
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	if aggregation == Mean {
+		total = total / float64(n)
+	}
+
+	return &total
+}
+
+// AggregateYoY rolls up the year-over-year percentages of a metric, given the
+// values of the metric in the last year. The value of the year before is
+// derived for each project from its percentage, both are rolled up like the
+// metric, and the percentage is recomputed from them. The projects missing
+// either value are ignored.
+func AggregateYoY(key string, lastYear []*float64, yoy []*float64) *float64 {
+	var current, previous []*float64
+	for i := range yoy {
+		if i >= len(lastYear) || lastYear[i] == nil || yoy[i] == nil || *yoy[i] == -100 {
+			continue
+		}
+
+		current = append(current, lastYear[i])
+		previous = append(previous, Float(*lastYear[i]/(1+*yoy[i]/100)))
+	}
+
+	total, before := Aggregate(key, current), Aggregate(key, previous)
+	if total == nil || before == nil || *before == 0 {
+		return nil
+	}
+
+	percentage := (*total - *before) / *before * 100
+	return &percentage
+}
+
+// RollupRow is a metric of a tab across the projects of a group, with the
+// rolled up value of the group.
+type RollupRow struct {
+	MatrixRow
+	Rollup      *float64    `json:"rollup"`
+	Aggregation Aggregation `json:"aggregation"`
+	Approximate bool        `json:"approximate"`
+}
+
+// RollupResponse is the JSON document of a tab rolled up for a group.
+type RollupResponse struct {
+	SchemaVersion int               `json:"schema_version"`
+	Group         string            `json:"group"`
+	From          string            `json:"from"`
+	To            string            `json:"to"`
+	Tab           string            `json:"tab"`
+	Projects      []project.Project `json:"projects"`
+	Rows          []RollupRow       `json:"rows"`
+}
+
+// NewRollup aggregates the rows of a tab across the projects of a group. The
+// year-over-year percentages are recomputed from the rows of the last year.
+func NewRollup(rows []MatrixRow) []RollupRow {
+	index := map[string]MatrixRow{}
+	for _, r := range rows {
+		index[r.Key] = r
+	}
+
+	rollup := make([]RollupRow, len(rows))
+	for i, r := range rows {
+		rollup[i] = RollupRow{
+			MatrixRow:   r,
+			Rollup:      Aggregate(r.Key, r.Values),
+			Aggregation: AggregationOf(r.Key),
+		}
+		approximate := rollup[i].Aggregation.Approximate()
+
+		if rollup[i].Aggregation == YearOverYear {
+			lastYear, ok := index[strings.Replace(r.Key, "_yoy_", "_last_year_", 1)]
+			if ok {
+				rollup[i].Rollup = AggregateYoY(lastYear.Key, lastYear.Values, r.Values)
+				approximate = AggregationOf(lastYear.Key).Approximate()
+			}
+		}
+
+		rollup[i].Approximate = approximate && rollup[i].Rollup != nil
+	}
+
+	return rollup
+}
+
+// NewRollupReport renders a tab with the metrics as rows, the projects of a
+// group as columns, and the rolled up value of the group, flagging the
+// approximations.
+func NewRollupReport(group string, projects []project.Project, pd period.Period, tab string, rows []RollupRow) Report {
+	table := Table{
+		Title:   fmt.Sprintf("Group: %s, Tab: %s, From: %s, To: %s", group, tab, pd.From.Format(period.Layout), pd.To.Format(period.Layout)),
+		Headers: []string{"Metric"},
+	}
+
+	records := Table{Headers: []string{"group", "tab", "metric"}}
+
+	for _, p := range projects {
+		table.Headers = append(table.Headers, p.Name)
+		records.Headers = append(records.Headers, p.Name)
+	}
+
+	table.Headers = append(table.Headers, group, "Aggregation")
+	records.Headers = append(records.Headers, group, "aggregation")
+
+	for _, r := range rows {
+		value := Metric{Value: r.Rollup, Precision: r.precision}.String()
+
+		displayed := value
+		if r.Approximate {
+			displayed = ApproximatePrefix + value
+		}
+
+		table.Rows = append(table.Rows, append(append([]string{r.Name}, r.breachCells(projects)...), displayed, string(r.Aggregation)))
+
+		// the aggregation column flags the approximations in the csv format
+		record := append([]string{group, tab, r.Key}, r.ValueCells()...)
+		records.Rows = append(records.Rows, append(record, value, string(r.Aggregation)))
+	}
+
+	return Report{
+		Tables: []Table{table},
+		Document: RollupResponse{
+			SchemaVersion: SchemaVersion,
+			Group:         group,
+			From:          pd.From.Format(period.Layout),
+			To:            pd.To.Format(period.Layout),
+			Tab:           tab,
+			Projects:      projects,
+			Rows:          rows,
+		},
+		Records: &records,
+	}
+}
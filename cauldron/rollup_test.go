@@ -0,0 +1,109 @@
+package cauldron_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+func TestAggregate(t *testing.T) {
+	values := []*float64{cauldron.Float(10), nil, cauldron.Float(20)}
+
+	testCases := []struct {
+		key         string
+		aggregation cauldron.Aggregation
+		expected    *float64
+	}{
+		{key: "commits_overview", aggregation: cauldron.Sum, expected: cauldron.Float(30)},
+		{key: "issues_median_time_to_close_overview", aggregation: cauldron.Mean, expected: cauldron.Float(15)},
+		{key: "reviews_time_open_average_performance_overview", aggregation: cauldron.Mean, expected: cauldron.Float(15)},
+		{key: "lines_commit_activity_overview", aggregation: cauldron.Mean, expected: cauldron.Float(15)},
+		{key: "active_people_git_community_overview", aggregation: cauldron.People, expected: cauldron.Float(30)},
+		{key: "issue_submitters_overview", aggregation: cauldron.People, expected: cauldron.Float(30)},
+		{key: "commits_yoy_overview", aggregation: cauldron.YearOverYear, expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.key, func(tt *testing.T) {
+			tt.Parallel()
+
+			if aggregation := cauldron.AggregationOf(testCase.key); aggregation != testCase.aggregation {
+				tt.Fatalf("expected aggregation %s but got %s", testCase.aggregation, aggregation)
+			}
+
+			got := cauldron.Aggregate(testCase.key, values)
+			if (got == nil) != (testCase.expected == nil) || (got != nil && *got != *testCase.expected) {
+				tt.Fatalf("expected %v but got %v", testCase.expected, got)
+			}
+		})
+	}
+
+	t.Run("all-missing", func(tt *testing.T) {
+		if got := cauldron.Aggregate("commits_overview", []*float64{nil, nil}); got != nil {
+			tt.Fatalf("expected nil but got %f", *got)
+		}
+	})
+}
+
+func TestAggregateYoY(t *testing.T) {
+	// the year before is 100 for the first project and 50 for the last one,
+	// and the second one is ignored as its percentage is missing
+	lastYear := []*float64{cauldron.Float(110), cauldron.Float(1000), cauldron.Float(40)}
+	yoy := []*float64{cauldron.Float(10), nil, cauldron.Float(-20)}
+
+	t.Run("sum", func(tt *testing.T) {
+		got := cauldron.AggregateYoY("commits_last_year_overview", lastYear, yoy)
+		if got == nil || *got != 0 {
+			tt.Fatalf("expected 0 but got %v", got)
+		}
+	})
+
+	t.Run("mean", func(tt *testing.T) {
+		got := cauldron.AggregateYoY("issues_median_time_to_close_last_year_overview", lastYear, yoy)
+		if got == nil || *got != 0 {
+			tt.Fatalf("expected 0 but got %v", got)
+		}
+	})
+
+	t.Run("all-missing", func(tt *testing.T) {
+		if got := cauldron.AggregateYoY("commits_last_year_overview", lastYear, []*float64{nil, nil, nil}); got != nil {
+			tt.Fatalf("expected nil but got %f", *got)
+		}
+	})
+}
+
+func TestRollupReport(t *testing.T) {
+	projects := []project.Project{{ID: 1, Name: "go"}, {ID: 2, Name: "java"}}
+	pd := period.Period{From: date(t, "2024-01-01"), To: date(t, "2024-03-31")}
+
+	printables := []cauldron.Printable{
+		&cauldron.Overview{CommitsOverview: cauldron.Int(100), CommitsLastYearOverview: cauldron.Int(110), CommitsYoyOverview: cauldron.Float(10), CommitAuthorsOverview: cauldron.Int(10), IssuesMedianTimeToCloseOverview: cauldron.Float(4)},
+		&cauldron.Overview{CommitsOverview: cauldron.Int(50), CommitsLastYearOverview: cauldron.Int(95), CommitsYoyOverview: cauldron.Float(-5), CommitAuthorsOverview: cauldron.Int(5), IssuesMedianTimeToCloseOverview: cauldron.Float(8)},
+	}
+
+	rows := cauldron.NewRollup(cauldron.NewMatrix(printables, false))
+	report := cauldron.NewRollupReport("testcontainers", projects, pd, "overview", []cauldron.RollupRow{rows[0], rows[6], rows[9], rows[18]})
+
+	w := &testWriter{}
+	if err := report.Write(w, "console"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `Group: testcontainers, Tab: overview, From: 2024-01-01, To: 2024-03-31
++--------------------------------------+-------+-------+----------------+-------------+
+|                METRIC                |  GO   | JAVA  | TESTCONTAINERS | AGGREGATION |
++--------------------------------------+-------+-------+----------------+-------------+
+| Commits Overview                     |   100 |    50 |            150 |         sum |
+| Commits YoY Overview                 | 10.00 | -5.00 |           2.50 |         yoy |
+| Commit Authors Overview              |    10 |     5 |            ~15 |      people |
+| Issues Median Time To Close Overview |  4.00 |  8.00 |          ~6.00 |        mean |
++--------------------------------------+-------+-------+----------------+-------------+
+
+`
+	if string(w.data) != expected {
+		t.Fatalf("expected \n%s but got \n%s", expected, string(w.data))
+	}
+}
@@ -0,0 +1,63 @@
+package cauldron
+
+import (
+	"reflect"
+
+	"github.com/mdelapenya/cauldrongo/schema"
+)
+
+// SchemaVersion is the version of the JSON documents, increased on breaking
+// changes, e.g. removing or renaming a field.
+const SchemaVersion = 1
+
+// OutputSchema returns the JSON Schema of the JSON documents: the metrics of
+// a tab, its time series, its comparison between two periods, its matrix of
+// projects, its rollup for a group, the stored history and the analyses of
+// it, the outcomes of the rules, the health scores, the progress of the goals,
+// the leaderboards and the top movers, or an array of them.
+func OutputSchema() schema.Schema {
+	g := schema.NewGenerator("json")
+
+	tabs := []schema.Schema{
+		g.For(reflect.TypeOf(Activity{})),
+		g.For(reflect.TypeOf(Community{})),
+		g.For(reflect.TypeOf(Overview{})),
+		g.For(reflect.TypeOf(Performance{})),
+	}
+
+	// the generic tabs are a flat object of metrics, as returned by Cauldron
+	g.Define(reflect.TypeOf(Generic{}), "cauldron.Generic", schema.Schema{
+		"type":                 "object",
+		"additionalProperties": schema.Schema{"type": []string{"number", "string", "null"}},
+	})
+	tabs = append(tabs, g.For(reflect.TypeOf(Generic{})))
+
+	// the computed metrics are numbers, or null if not available
+	g.Define(reflect.TypeOf(Computed{}), "cauldron.Computed", schema.Schema{
+		"type":                 "object",
+		"additionalProperties": schema.Schema{"type": []string{"number", "null"}},
+	})
+	tabs = append(tabs, g.For(reflect.TypeOf(Computed{})))
+
+	g.Define(reflect.TypeOf((*Printable)(nil)).Elem(), "cauldron.Tab", schema.Schema{"anyOf": tabs})
+
+	documents := schema.Schema{"anyOf": []schema.Schema{
+		g.For(reflect.TypeOf(JSONResponse{})),
+		g.For(reflect.TypeOf(SeriesResponse{})),
+		g.For(reflect.TypeOf(ComparisonResponse{})),
+		g.For(reflect.TypeOf(MatrixResponse{})),
+		g.For(reflect.TypeOf(RollupResponse{})),
+		g.For(reflect.TypeOf(HistoryResponse{})),
+		g.For(reflect.TypeOf(TrendResponse{})),
+		g.For(reflect.TypeOf(AnomalyResponse{})),
+		g.For(reflect.TypeOf(CheckResponse{})),
+		g.For(reflect.TypeOf(ScoreResponse{})),
+		g.For(reflect.TypeOf(GoalResponse{})),
+		g.For(reflect.TypeOf(LeaderboardResponse{})),
+		g.For(reflect.TypeOf(MoversResponse{})),
+	}}
+
+	return g.Root(schema.Schema{
+		"anyOf": []schema.Schema{documents, {"type": "array", "items": documents}},
+	}, "cauldrongo output", "The JSON documents written by cauldrongo, checked with their schema_version field.")
+}
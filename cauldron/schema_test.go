@@ -0,0 +1,36 @@
+package cauldron_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/schema"
+)
+
+func TestOutputSchema(t *testing.T) {
+	defs := cauldron.OutputSchema()["$defs"].(schema.Schema)
+
+	documents := []string{"JSONResponse", "SeriesResponse", "ComparisonResponse", "MatrixResponse", "RollupResponse", "HistoryResponse", "TrendResponse", "AnomalyResponse", "CheckResponse", "ScoreResponse", "GoalResponse", "LeaderboardResponse", "MoversResponse"}
+	for _, name := range documents {
+		name := name
+		t.Run(name, func(tt *testing.T) {
+			tt.Parallel()
+
+			def, ok := defs["cauldron."+name].(schema.Schema)
+			if !ok {
+				tt.Fatalf("missing definition of %s", name)
+			}
+
+			required := def["required"].([]string)
+			if len(required) == 0 || required[0] != "schema_version" {
+				tt.Errorf("expected schema_version to be required, got %v", required)
+			}
+		})
+	}
+
+	for _, name := range []string{"cauldron.Activity", "cauldron.Community", "cauldron.Overview", "cauldron.Performance", "cauldron.Generic", "cauldron.Tab", "project.Project"} {
+		if _, ok := defs[name]; !ok {
+			t.Errorf("missing definition of %s", name)
+		}
+	}
+}
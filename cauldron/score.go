@@ -0,0 +1,192 @@
+package cauldron
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/score"
+)
+
+// Contribution is the part of a metric in the health score of a project.
+type Contribution struct {
+	Key   string   `json:"key"`
+	Name  string   `json:"name"`
+	Value *float64 `json:"value"`
+	// Target is the fixed target of the metric, nil if it's normalized
+	// against the values of the projects.
+	Target *float64 `json:"target"`
+	// Normalized is the value normalized between 0 and 1, where 1 is the best.
+	Normalized *float64 `json:"normalized"`
+	Weight     float64  `json:"weight"`
+	// Points are the points of the metric in the 0-100 score, nil if it was
+	// not reported, which excludes its weight from the score.
+	Points *float64 `json:"points"`
+
+	precision int
+}
+
+// Score is the health score of a project, from 0 to 100.
+type Score struct {
+	ProjectID   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	// Score is nil if none of the metrics was reported.
+	Score         *float64       `json:"score"`
+	Contributions []Contribution `json:"contributions"`
+}
+
+// ScoreResponse is the JSON document of the health scores.
+type ScoreResponse struct {
+	SchemaVersion int     `json:"schema_version"`
+	Scores        []Score `json:"scores"`
+}
+
+// NewScores computes the health score of each project from its printables,
+// combining the metrics normalized against their targets, or against the
+// values of all the projects, with their weights. The metrics not reported
+// are excluded from the score of a project. The scores are sorted from the
+// highest.
+func NewScores(projects []project.Project, metrics []score.Metric, printables [][]Printable) []Score {
+	// values[project][metric]
+	values := make([][]Metric, len(projects))
+	for p := range projects {
+		values[p] = make([]Metric, len(metrics))
+
+		found := map[string]Metric{}
+		for _, printable := range printables[p] {
+			for _, m := range printable.Metrics() {
+				found[m.Key] = m
+			}
+		}
+
+		for i, def := range metrics {
+			m, ok := found[def.Key]
+			if !ok {
+				m = Metric{Key: def.Key, Name: MetricName(def.Key)}
+			}
+
+			values[p][i] = m
+		}
+	}
+
+	scores := make([]Score, len(projects))
+	for p, proj := range projects {
+		scores[p] = Score{ProjectID: proj.ID, ProjectName: proj.Name, Contributions: make([]Contribution, len(metrics))}
+
+		total := 0.0
+		for i, def := range metrics {
+			m := values[p][i]
+			c := Contribution{Key: def.Key, Name: m.Name, Value: m.Value, Target: def.Target, Weight: def.Weighting(), precision: m.Precision}
+
+			if m.Value != nil {
+				lower := LowerIsBetter(def.Key)
+				if def.Target != nil {
+					c.Normalized = Float(score.AgainstTarget(*m.Value, *def.Target, lower))
+				} else {
+					lo, hi := valueRange(values, i)
+					c.Normalized = Float(score.AgainstRange(*m.Value, lo, hi, lower))
+				}
+
+				total += c.Weight
+			}
+
+			scores[p].Contributions[i] = c
+		}
+
+		if total == 0 {
+			continue
+		}
+
+		sum := 0.0
+		for i, c := range scores[p].Contributions {
+			if c.Normalized == nil {
+				continue
+			}
+
+			points := c.Weight * *c.Normalized / total * 100
+			scores[p].Contributions[i].Points = Float(points)
+			sum += points
+		}
+
+		scores[p].Score = Float(sum)
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[j].Score == nil {
+			return scores[i].Score != nil
+		}
+
+		return scores[i].Score != nil && *scores[i].Score > *scores[j].Score
+	})
+
+	return scores
+}
+
+// valueRange returns the minimum and maximum values of a metric across the
+// projects reporting it.
+func valueRange(values [][]Metric, metric int) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, projectValues := range values {
+		if v := projectValues[metric].Value; v != nil {
+			lo = math.Min(lo, *v)
+			hi = math.Max(hi, *v)
+		}
+	}
+
+	return lo, hi
+}
+
+// NewScoreReport renders the health scores as a ranking table and a table
+// with the contribution of each metric, and as one record per project and
+// metric in the csv format.
+func NewScoreReport(scores []Score) Report {
+	ranking := Table{
+		Title:   "Health scores",
+		Headers: []string{"Rank", "Project", "Score"},
+	}
+
+	breakdown := Table{
+		Title:   "Score breakdown",
+		Headers: []string{"Project", "Metric", "Value", "Target", "Normalized", "Weight", "Points"},
+	}
+
+	records := Table{
+		Headers: []string{"project_id", "project_name", "score", "metric", "value", "target", "normalized", "weight", "points"},
+	}
+
+	for i, s := range scores {
+		total := Metric{Value: s.Score, Precision: 1}.String()
+		ranking.Rows = append(ranking.Rows, []string{strconv.Itoa(i + 1), s.ProjectName, total})
+
+		for _, c := range s.Contributions {
+			value := Metric{Value: c.Value, Precision: c.precision}.String()
+			normalized := Metric{Value: c.Normalized, Precision: 2}.String()
+			weight := strconv.FormatFloat(c.Weight, 'f', -1, 64)
+			points := Metric{Value: c.Points, Precision: 1}.String()
+
+			target := "projects"
+			if c.Target != nil {
+				target = strconv.FormatFloat(*c.Target, 'f', -1, 64)
+			}
+
+			breakdown.Rows = append(breakdown.Rows, []string{s.ProjectName, c.Name, value, target, normalized, weight, points})
+
+			recordTarget := ""
+			if c.Target != nil {
+				recordTarget = target
+			}
+			records.Rows = append(records.Rows, []string{fmt.Sprint(s.ProjectID), s.ProjectName, total, c.Key, value, recordTarget, normalized, weight, points})
+		}
+	}
+
+	return Report{
+		Tables: []Table{ranking, breakdown},
+		Document: ScoreResponse{
+			SchemaVersion: SchemaVersion,
+			Scores:        scores,
+		},
+		Records: &records,
+	}
+}
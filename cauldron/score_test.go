@@ -0,0 +1,61 @@
+package cauldron_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/score"
+)
+
+func TestScores(t *testing.T) {
+	projects := []project.Project{
+		{ID: 1, Name: "slow"},
+		{ID: 2, Name: "fast"},
+		{ID: 3, Name: "empty"},
+	}
+
+	metrics := []score.Metric{
+		{Key: "commits_activity_overview", Weight: cauldron.Float(3), Target: cauldron.Float(100)},
+		{Key: "issues_median_time_to_close_overview"},
+	}
+
+	printables := [][]cauldron.Printable{
+		{&cauldron.Activity{CommitsActivityOverview: cauldron.Int(50)}, &cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(30)}},
+		{&cauldron.Activity{CommitsActivityOverview: cauldron.Int(200)}, &cauldron.Overview{IssuesMedianTimeToCloseOverview: cauldron.Float(10)}},
+		{&cauldron.Activity{}, &cauldron.Overview{}},
+	}
+
+	scores := cauldron.NewScores(projects, metrics, printables)
+
+	if scores[0].ProjectName != "fast" || *scores[0].Score != 100 {
+		t.Fatalf("expected fast to score 100 but got %+v", scores[0])
+	}
+
+	// the commits reach half the target, weighing 3 out of 4, and the time to
+	// close is the worst of the projects
+	if scores[1].ProjectName != "slow" || *scores[1].Score != 37.5 || *scores[1].Contributions[0].Points != 37.5 || *scores[1].Contributions[1].Points != 0 {
+		t.Fatalf("unexpected score of slow %+v", scores[1])
+	}
+
+	if scores[2].ProjectName != "empty" || scores[2].Score != nil || scores[2].Contributions[0].Points != nil {
+		t.Fatalf("expected no score for the project without metrics but got %+v", scores[2])
+	}
+
+	buf := &bytes.Buffer{}
+	if err := cauldron.NewScoreReport(scores).Write(buf, "csv"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "project_id,project_name,score,metric,value,target,normalized,weight,points\n" +
+		"2,fast,100.0,commits_activity_overview,200,100,1.00,3,75.0\n" +
+		"2,fast,100.0,issues_median_time_to_close_overview,10.00,,1.00,1,25.0\n" +
+		"1,slow,37.5,commits_activity_overview,50,100,0.50,3,37.5\n" +
+		"1,slow,37.5,issues_median_time_to_close_overview,30.00,,0.00,1,0.0\n" +
+		"3,empty,,commits_activity_overview,,100,,3,\n" +
+		"3,empty,,issues_median_time_to_close_overview,,,,1,\n"
+	if buf.String() != expected {
+		t.Fatalf("expected\n%s\nbut got\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,110 @@
+package cauldron
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+// Point is the value of a metric in one window of a time series.
+type Point struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Value *float64 `json:"value"`
+}
+
+// Series is the evolution of a metric over consecutive windows.
+type Series struct {
+	Key    string  `json:"key"`
+	Name   string  `json:"name"`
+	Points []Point `json:"points"`
+
+	precision int
+}
+
+// SeriesResponse is the JSON document of the time series of a tab.
+type SeriesResponse struct {
+	SchemaVersion int             `json:"schema_version"`
+	Project       project.Project `json:"project"`
+	From          string          `json:"from"`
+	To            string          `json:"to"`
+	Tab           string          `json:"tab"`
+	Interval      string          `json:"interval"`
+	Series        []Series        `json:"series"`
+}
+
+// NewSeries builds one series per metric from the printables of a tab, each
+// one fetched for the window at the same index. The metrics are sorted by
+// their first appearance, as generic tabs may not report all of them in
+// every window.
+func NewSeries(windows []period.Period, printables []Printable) []Series {
+	series := []Series{}
+	index := map[string]int{}
+
+	for i, p := range printables {
+		for _, m := range p.Metrics() {
+			idx, ok := index[m.Key]
+			if !ok {
+				idx = len(series)
+				index[m.Key] = idx
+
+				points := make([]Point, len(windows))
+				for j, w := range windows {
+					points[j] = Point{From: w.From.Format(period.Layout), To: w.To.Format(period.Layout)}
+				}
+
+				series = append(series, Series{Key: m.Key, Name: m.Name, Points: points, precision: m.Precision})
+			}
+
+			series[idx].Points[i].Value = m.Value
+		}
+	}
+
+	return series
+}
+
+// NewSeriesReport renders the time series of a tab as a table with one column
+// per window, and as one record per point in the csv format.
+func NewSeriesReport(p project.Project, pd period.Period, interval period.Interval, tab string, windows []period.Period, series []Series) Report {
+	headers := []string{"Metric (" + p.Name + ")"}
+	for _, w := range windows {
+		headers = append(headers, interval.Label(w))
+	}
+
+	table := Table{
+		Title:   fmt.Sprintf("Project: %s (%d), Tab: %s, From: %s, To: %s, Interval: %s", p.Name, p.ID, tab, pd.From.Format(period.Layout), pd.To.Format(period.Layout), interval),
+		Headers: headers,
+	}
+
+	records := Table{
+		Headers: []string{"project_id", "project_name", "tab", "metric", "from", "to", "value"},
+	}
+
+	for _, s := range series {
+		row := []string{s.Name}
+		for _, point := range s.Points {
+			m := Metric{Value: point.Value, Precision: s.precision}
+			row = append(row, m.String()+breach(p.Thresholds, s.Key, point.Value))
+
+			records.Rows = append(records.Rows, []string{strconv.Itoa(p.ID), p.Name, tab, s.Key, point.From, point.To, m.String()})
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	return Report{
+		Tables: []Table{table},
+		Document: SeriesResponse{
+			SchemaVersion: SchemaVersion,
+			Project:       p,
+			From:          pd.From.Format(period.Layout),
+			To:            pd.To.Format(period.Layout),
+			Tab:           tab,
+			Interval:      string(interval),
+			Series:        series,
+		},
+		Records: &records,
+	}
+}
@@ -0,0 +1,111 @@
+package cauldron_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+)
+
+func TestSeriesReport(t *testing.T) {
+	pd := period.Period{From: date(t, "2024-01-15"), To: date(t, "2024-03-10")}
+	windows := pd.Split(period.Month)
+
+	printables := []cauldron.Printable{
+		&cauldron.Community{ActivePeopleGitCommunityOverview: cauldron.Int(8), OnboardingsGitCommunityOverview: cauldron.Int(2)},
+		&cauldron.Community{ActivePeopleGitCommunityOverview: cauldron.Int(11), OnboardingsGitCommunityOverview: cauldron.Int(0)},
+		&cauldron.Community{ActivePeopleGitCommunityOverview: cauldron.Int(9)},
+	}
+
+	series := cauldron.NewSeries(windows, printables)
+	if len(series) != 6 {
+		t.Fatalf("expected 6 series but got %d", len(series))
+	}
+
+	report := cauldron.NewSeriesReport(testProject, pd, period.Month, "community-overview", windows, series[3:4])
+
+	t.Run("console", func(tt *testing.T) {
+		w := &testWriter{}
+		if err := report.Write(w, "console"); err != nil {
+			tt.Fatal(err)
+		}
+
+		expected := `Project: Test Project (1), Tab: community-overview, From: 2024-01-15, To: 2024-03-10, Interval: month
++------------------------------------+---------+---------+---------+
+|       METRIC (TEST PROJECT)        | 2024-01 | 2024-02 | 2024-03 |
++------------------------------------+---------+---------+---------+
+| Onboardings Git Community Overview |       2 |       0 |     n/a |
++------------------------------------+---------+---------+---------+
+
+`
+		if string(w.data) != expected {
+			tt.Fatalf("expected \n%s but got \n%s", expected, string(w.data))
+		}
+	})
+
+	t.Run("csv", func(tt *testing.T) {
+		w := &testWriter{}
+		if err := report.Write(w, "csv"); err != nil {
+			tt.Fatal(err)
+		}
+
+		expected := `project_id,project_name,tab,metric,from,to,value
+1,Test Project,community-overview,onboardings_git_community_overview,2024-01-15,2024-01-31,2
+1,Test Project,community-overview,onboardings_git_community_overview,2024-02-01,2024-02-29,0
+1,Test Project,community-overview,onboardings_git_community_overview,2024-03-01,2024-03-10,
+`
+		if string(w.data) != expected {
+			tt.Fatalf("expected \n%s but got \n%s", expected, string(w.data))
+		}
+	})
+
+	t.Run("json", func(tt *testing.T) {
+		w := &testWriter{}
+		if err := report.Write(w, "json"); err != nil {
+			tt.Fatal(err)
+		}
+
+		expected := `{
+  "schema_version": 1,
+  "project": {
+    "id": 1,
+    "name": "Test Project",
+    "RepoURL": [
+      "http://example.com/repo",
+      "http://example.com/repo.git"
+    ]
+  },
+  "from": "2024-01-15",
+  "to": "2024-03-10",
+  "tab": "community-overview",
+  "interval": "month",
+  "series": [
+    {
+      "key": "onboardings_git_community_overview",
+      "name": "Onboardings Git Community Overview",
+      "points": [
+        {
+          "from": "2024-01-15",
+          "to": "2024-01-31",
+          "value": 2
+        },
+        {
+          "from": "2024-02-01",
+          "to": "2024-02-29",
+          "value": 0
+        },
+        {
+          "from": "2024-03-01",
+          "to": "2024-03-10",
+          "value": null
+        }
+      ]
+    }
+  ]
+}
+`
+		if string(w.data) != expected {
+			tt.Fatalf("expected \n%s but got \n%s", expected, string(w.data))
+		}
+	})
+}
@@ -0,0 +1,358 @@
+package cauldron
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/store"
+)
+
+const (
+	// Up is the direction of a metric growing over its history
+	Up = "up"
+	// Down is the direction of a metric decreasing over its history
+	Down = "down"
+	// Flat is the direction of a metric barely changing over its history
+	Flat = "flat"
+)
+
+// flatThreshold is the change of the fitted line over the whole history,
+// relative to the largest value, below which a trend is flat.
+const flatThreshold = 0.01
+
+// sparks are the bars of a sparkline, from the lowest to the highest value.
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// TrendPoint is the value of a metric in a stored snapshot, or in a window of
+// a fetched time series, which has no fetch time.
+type TrendPoint struct {
+	FetchedAt string   `json:"fetched_at,omitempty"`
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Value     *float64 `json:"value"`
+}
+
+// Trend is the evolution of a metric of a project over its stored
+// snapshots, from the oldest to the newest period.
+type Trend struct {
+	ProjectID   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Tab         string `json:"tab"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	// Days is the length of the periods of the points, which is about the
+	// same for all of them, e.g. from 28 to 31 days for months.
+	Days   int          `json:"days"`
+	Points []TrendPoint `json:"points"`
+	Min    *float64     `json:"min"`
+	Max    *float64     `json:"max"`
+	Last   *float64     `json:"last"`
+	// Slope is the change per snapshot of the line fitting the values.
+	Slope     *float64 `json:"slope"`
+	Direction string   `json:"direction"`
+	// Status tells if the direction is better or worse for the metric, and
+	// it's empty for flat trends.
+	Status string `json:"status,omitempty"`
+
+	precision int
+}
+
+// TrendResponse is the JSON document of the trends of the stored metrics.
+type TrendResponse struct {
+	SchemaVersion int     `json:"schema_version"`
+	Trends        []Trend `json:"trends"`
+}
+
+// NewTrends builds the trend of every metric of every project from the stored
+// snapshots, sorted by their first appearance, skipping the metrics without
+// values. The snapshots of periods of different lengths, e.g. of the last
+// month and of the last year, have separate trends, and only the latest fetch
+// of each period is kept. If metric keys are given, only their trends are built.
+func NewTrends(snapshots []store.Snapshot, keys []string) ([]Trend, error) {
+	wanted := map[string]bool{}
+	for _, k := range keys {
+		wanted[k] = true
+	}
+
+	trends := []Trend{}
+	index := map[string][]int{}
+
+	for _, s := range snapshots {
+		result, err := Decode(s.Tab, s.Response)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding snapshot %d: %w", s.ID, err)
+		}
+
+		days := spanDays(s.From, s.To)
+
+		for _, m := range result.Printable.Metrics() {
+			if len(wanted) > 0 && !wanted[m.Key] {
+				continue
+			}
+
+			id := strconv.Itoa(s.ProjectID) + "/" + m.Key
+			idx := -1
+			for _, i := range index[id] {
+				if sameSpan(trends[i].Days, days) {
+					idx = i
+					break
+				}
+			}
+
+			if idx < 0 {
+				idx = len(trends)
+				index[id] = append(index[id], idx)
+				trends = append(trends, Trend{ProjectID: s.ProjectID, ProjectName: s.ProjectName, Tab: s.Tab, Key: m.Key, Name: m.Name, Days: days, Points: []TrendPoint{}, precision: m.Precision})
+			}
+
+			trends[idx].add(TrendPoint{
+				FetchedAt: s.FetchedAt.Format(time.RFC3339),
+				From:      s.From,
+				To:        s.To,
+				Value:     m.Value,
+			})
+		}
+	}
+
+	// the metrics never reported have no trend
+	reported := []Trend{}
+	for _, t := range trends {
+		sort.SliceStable(t.Points, func(i, j int) bool {
+			return t.Points[i].From < t.Points[j].From
+		})
+
+		t.summarize()
+		if t.Last != nil {
+			reported = append(reported, t)
+		}
+	}
+
+	return reported, nil
+}
+
+// add appends a point to the trend, replacing the point of the same period,
+// as the snapshots are sorted by their fetch time.
+func (t *Trend) add(point TrendPoint) {
+	for i, p := range t.Points {
+		if p.From == point.From && p.To == point.To {
+			t.Points[i] = point
+			return
+		}
+	}
+
+	t.Points = append(t.Points, point)
+}
+
+// spanDays returns the number of days of a period, or 0 if its dates are
+// not valid.
+func spanDays(from string, to string) int {
+	f, err := time.Parse(period.Layout, from)
+	if err != nil {
+		return 0
+	}
+
+	t, err := time.Parse(period.Layout, to)
+	if err != nil {
+		return 0
+	}
+
+	return int(math.Round(t.Sub(f).Hours() / 24))
+}
+
+// sameSpan returns true if the lengths of two periods differ by at most a
+// tenth of the longest, so that calendar months, quarters and years of
+// different lengths are comparable.
+func sameSpan(a int, b int) bool {
+	if a == b {
+		return true
+	}
+
+	if a <= 0 || b <= 0 {
+		return false
+	}
+
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return diff*10 <= max(a, b)
+}
+
+// summarize computes the statistics of the trend from its points.
+func (t *Trend) summarize() {
+	values := make([]*float64, len(t.Points))
+	for i, p := range t.Points {
+		values[i] = p.Value
+
+		if p.Value == nil {
+			continue
+		}
+
+		if t.Min == nil || *p.Value < *t.Min {
+			t.Min = Float(*p.Value)
+		}
+
+		if t.Max == nil || *p.Value > *t.Max {
+			t.Max = Float(*p.Value)
+		}
+
+		t.Last = Float(*p.Value)
+	}
+
+	t.Slope = Slope(values)
+	t.Direction = Flat
+
+	if t.Slope == nil {
+		return
+	}
+
+	// the change of the fitted line from the first to the last snapshot
+	change := *t.Slope * float64(len(values)-1)
+	largest := math.Max(math.Abs(*t.Min), math.Abs(*t.Max))
+	if largest == 0 || math.Abs(change) <= flatThreshold*largest {
+		return
+	}
+
+	t.Direction = Up
+	if change < 0 {
+		t.Direction = Down
+	}
+
+	if (change < 0) == LowerIsBetter(t.Key) {
+		t.Status = Better
+	} else {
+		t.Status = Worse
+	}
+}
+
+// Indicator renders the direction of the trend as an arrow.
+func (t Trend) Indicator() string {
+	switch t.Direction {
+	case Up:
+		return "↑ " + Up
+	case Down:
+		return "↓ " + Down
+	default:
+		return "→ " + Flat
+	}
+}
+
+// Sparkline renders the values as Unicode bars scaled between their minimum
+// and maximum, with a dot for the missing ones.
+func Sparkline(values []*float64) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if v != nil {
+			lo = math.Min(lo, *v)
+			hi = math.Max(hi, *v)
+		}
+	}
+
+	line := make([]rune, len(values))
+	for i, v := range values {
+		switch {
+		case v == nil:
+			line[i] = '·'
+		case hi == lo:
+			line[i] = sparks[len(sparks)/2-1]
+		default:
+			line[i] = sparks[int((*v-lo)/(hi-lo)*float64(len(sparks)-1)+0.5)]
+		}
+	}
+
+	return string(line)
+}
+
+// Slope returns the slope of the least squares line fitting the values by
+// their index, skipping the missing ones, or nil if there are less than two.
+func Slope(values []*float64) *float64 {
+	var n, sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+
+		x := float64(i)
+		n++
+		sumX += x
+		sumY += *v
+		sumXY += x * *v
+		sumXX += x * x
+	}
+
+	if n < 2 {
+		return nil
+	}
+
+	return Float((n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX))
+}
+
+// NewTrendReport renders the trends as a table per project, with a sparkline
+// and the statistics of each metric, and as one record per point in the csv
+// format.
+func NewTrendReport(trends []Trend) Report {
+	tables := []Table{}
+	index := map[int]int{}
+
+	records := Table{
+		Headers: []string{"project_id", "project_name", "tab", "metric", "days", "fetched_at", "from", "to", "value"},
+	}
+
+	for _, t := range trends {
+		idx, ok := index[t.ProjectID]
+		if !ok {
+			idx = len(tables)
+			index[t.ProjectID] = idx
+			tables = append(tables, Table{
+				Title:   fmt.Sprintf("Trends: %s (%d)", t.ProjectName, t.ProjectID),
+				Headers: []string{"Metric", "Tab", "Days", "Trend", "Min", "Max", "Last", "Slope", "Direction"},
+			})
+		}
+
+		values := make([]*float64, len(t.Points))
+		for i, p := range t.Points {
+			values[i] = p.Value
+
+			m := Metric{Value: p.Value, Precision: t.precision}
+			records.Rows = append(records.Rows, []string{strconv.Itoa(t.ProjectID), t.ProjectName, t.Tab, t.Key, strconv.Itoa(t.Days), p.FetchedAt, p.From, p.To, m.String()})
+		}
+
+		direction := t.Indicator()
+		if t.Status != "" {
+			direction += " (" + t.Status + ")"
+		}
+
+		tables[idx].Rows = append(tables[idx].Rows, []string{
+			t.Name,
+			t.Tab,
+			strconv.Itoa(t.Days),
+			Sparkline(values),
+			Metric{Value: t.Min, Precision: t.precision}.String(),
+			Metric{Value: t.Max, Precision: t.precision}.String(),
+			Metric{Value: t.Last, Precision: t.precision}.String(),
+			Metric{Value: t.Slope, Precision: 2}.String(),
+			direction,
+		})
+	}
+
+	if len(tables) == 0 {
+		tables = append(tables, Table{
+			Title:   "Trends",
+			Headers: []string{"Metric", "Tab", "Days", "Trend", "Min", "Max", "Last", "Slope", "Direction"},
+		})
+	}
+
+	return Report{
+		Tables: tables,
+		Document: TrendResponse{
+			SchemaVersion: SchemaVersion,
+			Trends:        trends,
+		},
+		Records: &records,
+	}
+}
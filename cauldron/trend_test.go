@@ -0,0 +1,121 @@
+package cauldron_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/store"
+)
+
+func TestSparkline(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []*float64
+		expected string
+	}{
+		{name: "rising", values: []*float64{cauldron.Float(0), cauldron.Float(1), cauldron.Float(7)}, expected: "▁▂█"},
+		{name: "constant", values: []*float64{cauldron.Float(5), cauldron.Float(5)}, expected: "▄▄"},
+		{name: "missing", values: []*float64{cauldron.Float(0), nil, cauldron.Float(7)}, expected: "▁·█"},
+		{name: "empty", values: []*float64{}, expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			if got := cauldron.Sparkline(testCase.values); got != testCase.expected {
+				tt.Fatalf("expected %q but got %q", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestSlope(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []*float64
+		expected *float64
+	}{
+		{name: "linear", values: []*float64{cauldron.Float(1), cauldron.Float(3), cauldron.Float(5)}, expected: cauldron.Float(2)},
+		{name: "missing", values: []*float64{cauldron.Float(1), nil, cauldron.Float(5)}, expected: cauldron.Float(2)},
+		{name: "single", values: []*float64{cauldron.Float(1), nil}, expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			got := cauldron.Slope(testCase.values)
+			if (got == nil) != (testCase.expected == nil) || (got != nil && *got != *testCase.expected) {
+				tt.Fatalf("expected %v but got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestTrends(t *testing.T) {
+	day := time.Date(2024, time.April, 1, 10, 0, 0, 0, time.UTC)
+	snapshot := func(id int64, days int, from string, to string, response string) store.Snapshot {
+		return store.Snapshot{ID: id, ProjectID: 1, ProjectName: "go", Tab: "performance-overview", From: from, To: to, FetchedAt: day.AddDate(0, 0, days), Response: []byte(response)}
+	}
+
+	snapshots := []store.Snapshot{
+		snapshot(1, 0, "2024-01-01", "2024-02-01", `{"open_issues_performance_overview":60,"open_reviews_performance_overview":10}`),
+		snapshot(2, 1, "2024-02-01", "2024-03-01", `{"open_issues_performance_overview":70,"open_reviews_performance_overview":10}`),
+		snapshot(3, 2, "2023-04-01", "2024-04-01", `{"open_issues_performance_overview":500,"open_reviews_performance_overview":90}`),
+		snapshot(4, 3, "2024-03-01", "2024-04-01", `{"open_issues_performance_overview":40,"open_reviews_performance_overview":10}`),
+		// a refetch of February, replacing the first fetch
+		snapshot(5, 4, "2024-02-01", "2024-03-01", `{"open_issues_performance_overview":50,"open_reviews_performance_overview":10}`),
+	}
+
+	trends, err := cauldron.NewTrends(snapshots, []string{"open_issues_performance_overview", "open_reviews_performance_overview"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the monthly and the yearly snapshots have separate trends
+	if len(trends) != 4 {
+		t.Fatalf("expected 4 trends but got %d", len(trends))
+	}
+
+	issues := trends[0]
+	if issues.Days != 31 || len(issues.Points) != 3 {
+		t.Fatalf("expected 3 monthly points but got %d of %d days", len(issues.Points), issues.Days)
+	}
+
+	if *issues.Min != 40 || *issues.Max != 60 || *issues.Last != 40 || *issues.Slope != -10 {
+		t.Fatalf("unexpected statistics %v %v %v %v", *issues.Min, *issues.Max, *issues.Last, *issues.Slope)
+	}
+
+	// fewer open issues are better
+	if issues.Direction != cauldron.Down || issues.Status != cauldron.Better {
+		t.Fatalf("expected a better downwards trend but got %s %s", issues.Direction, issues.Status)
+	}
+
+	if reviews := trends[1]; reviews.Direction != cauldron.Flat || reviews.Status != "" {
+		t.Fatalf("expected a flat trend but got %s %s", reviews.Direction, reviews.Status)
+	}
+
+	if yearly := trends[2]; yearly.Days != 366 || len(yearly.Points) != 1 || *yearly.Last != 500 {
+		t.Fatalf("unexpected yearly trend %+v", yearly)
+	}
+
+	report := cauldron.NewTrendReport(trends)
+	if row := report.Tables[0].Rows[0]; row[2] != "31" || row[3] != "█▅▁" || row[8] != "↓ down (better)" {
+		t.Fatalf("unexpected row %v", row)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := report.Write(buf, "csv"); err != nil {
+		t.Fatal(err)
+	}
+
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 9 {
+		t.Fatalf("expected a record per point but got %d lines", len(lines))
+	}
+}
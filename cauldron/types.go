@@ -1,9 +1,5 @@
 package cauldron
 
-import (
-	"fmt"
-)
-
 /*
 	{
 	    "commits_activity_overview": 15,
@@ -18,28 +14,43 @@ import (
 	}
 */
 type Activity struct {
-	CommitsActivityOverview         int    `json:"commits_activity_overview"`
-	LinesCommitActivityOverview     string `json:"lines_commit_activity_overview"`
-	LinesCommitFileActivityOverview string `json:"lines_commit_file_activity_overview"`
-	IssuesCreatedActivityOverview   int    `json:"issues_created_activity_overview"`
-	IssuesClosedActivityOverview    int    `json:"issues_closed_activity_overview"`
-	IssuesOpenActivityOverview      int    `json:"issues_open_activity_overview"`
-	ReviewsCreatedActivityOverview  int    `json:"reviews_created_activity_overview"`
-	ReviewsClosedActivityOverview   int    `json:"reviews_closed_activity_overview"`
-	ReviewsOpenActivityOverview     int    `json:"reviews_open_activity_overview"`
+	CommitsActivityOverview         *int    `json:"commits_activity_overview"`
+	LinesCommitActivityOverview     *string `json:"lines_commit_activity_overview"`
+	LinesCommitFileActivityOverview *string `json:"lines_commit_file_activity_overview"`
+	IssuesCreatedActivityOverview   *int    `json:"issues_created_activity_overview"`
+	IssuesClosedActivityOverview    *int    `json:"issues_closed_activity_overview"`
+	IssuesOpenActivityOverview      *int    `json:"issues_open_activity_overview"`
+	ReviewsCreatedActivityOverview  *int    `json:"reviews_created_activity_overview"`
+	ReviewsClosedActivityOverview   *int    `json:"reviews_closed_activity_overview"`
+	ReviewsOpenActivityOverview     *int    `json:"reviews_open_activity_overview"`
+	// Extra keeps the fields of the response not modelled by the struct
+	Extra map[string]any `json:"-"`
+}
+
+func (a Activity) MarshalJSON() ([]byte, error) {
+	type alias Activity
+	return marshalWithExtra(alias(a), a.Extra)
+}
+
+func (a *Activity) setExtra(extra map[string]any) {
+	a.Extra = extra
 }
 
 func (a *Activity) Data() [][]string {
-	return [][]string{
-		{"Commits Activity Overview", fmt.Sprintf("%d", a.CommitsActivityOverview)},
-		{"Lines Commit Activity Overview", a.LinesCommitActivityOverview},
-		{"Lines Commit File Activity Overview", a.LinesCommitFileActivityOverview},
-		{"Issues Created Activity Overview", fmt.Sprintf("%d", a.IssuesCreatedActivityOverview)},
-		{"Issues Closed Activity Overview", fmt.Sprintf("%d", a.IssuesClosedActivityOverview)},
-		{"Issues Open Activity Overview", fmt.Sprintf("%d", a.IssuesOpenActivityOverview)},
-		{"Reviews Created Activity Overview", fmt.Sprintf("%d", a.ReviewsCreatedActivityOverview)},
-		{"Reviews Closed Activity Overview", fmt.Sprintf("%d", a.ReviewsClosedActivityOverview)},
-		{"Reviews Open Activity Overview", fmt.Sprintf("%d", a.ReviewsOpenActivityOverview)},
+	return rows(a.Metrics())
+}
+
+func (a *Activity) Metrics() []Metric {
+	return []Metric{
+		intMetric("commits_activity_overview", "Commits Activity Overview", a.CommitsActivityOverview),
+		stringMetric("lines_commit_activity_overview", "Lines Commit Activity Overview", a.LinesCommitActivityOverview),
+		stringMetric("lines_commit_file_activity_overview", "Lines Commit File Activity Overview", a.LinesCommitFileActivityOverview),
+		intMetric("issues_created_activity_overview", "Issues Created Activity Overview", a.IssuesCreatedActivityOverview),
+		intMetric("issues_closed_activity_overview", "Issues Closed Activity Overview", a.IssuesClosedActivityOverview),
+		intMetric("issues_open_activity_overview", "Issues Open Activity Overview", a.IssuesOpenActivityOverview),
+		intMetric("reviews_created_activity_overview", "Reviews Created Activity Overview", a.ReviewsCreatedActivityOverview),
+		intMetric("reviews_closed_activity_overview", "Reviews Closed Activity Overview", a.ReviewsClosedActivityOverview),
+		intMetric("reviews_open_activity_overview", "Reviews Open Activity Overview", a.ReviewsOpenActivityOverview),
 	}
 }
 
@@ -54,22 +65,37 @@ func (a *Activity) Data() [][]string {
 	}
 */
 type Community struct {
-	ActivePeopleGitCommunityOverview     int `json:"active_people_git_community_overview"`
-	ActivePeopleIssuesCommunityOverview  int `json:"active_people_issues_community_overview"`
-	ActivePeoplePatchesCommunityOverview int `json:"active_people_patches_community_overview"`
-	OnboardingsGitCommunityOverview      int `json:"onboardings_git_community_overview"`
-	OnboardingsIssuesCommunityOverview   int `json:"onboardings_issues_community_overview"`
-	OnboardingsPatchesCommunityOverview  int `json:"onboardings_patches_community_overview"`
+	ActivePeopleGitCommunityOverview     *int `json:"active_people_git_community_overview"`
+	ActivePeopleIssuesCommunityOverview  *int `json:"active_people_issues_community_overview"`
+	ActivePeoplePatchesCommunityOverview *int `json:"active_people_patches_community_overview"`
+	OnboardingsGitCommunityOverview      *int `json:"onboardings_git_community_overview"`
+	OnboardingsIssuesCommunityOverview   *int `json:"onboardings_issues_community_overview"`
+	OnboardingsPatchesCommunityOverview  *int `json:"onboardings_patches_community_overview"`
+	// Extra keeps the fields of the response not modelled by the struct
+	Extra map[string]any `json:"-"`
+}
+
+func (c Community) MarshalJSON() ([]byte, error) {
+	type alias Community
+	return marshalWithExtra(alias(c), c.Extra)
+}
+
+func (c *Community) setExtra(extra map[string]any) {
+	c.Extra = extra
 }
 
 func (c *Community) Data() [][]string {
-	return [][]string{
-		{"Active People Git Community Overview", fmt.Sprintf("%d", c.ActivePeopleGitCommunityOverview)},
-		{"Active People Issues Community Overview", fmt.Sprintf("%d", c.ActivePeopleIssuesCommunityOverview)},
-		{"Active People Patches Community Overview", fmt.Sprintf("%d", c.ActivePeoplePatchesCommunityOverview)},
-		{"Onboardings Git Community Overview", fmt.Sprintf("%d", c.OnboardingsGitCommunityOverview)},
-		{"Onboardings Issues Community Overview", fmt.Sprintf("%d", c.OnboardingsIssuesCommunityOverview)},
-		{"Onboardings Patches Community Overview", fmt.Sprintf("%d", c.OnboardingsPatchesCommunityOverview)},
+	return rows(c.Metrics())
+}
+
+func (c *Community) Metrics() []Metric {
+	return []Metric{
+		intMetric("active_people_git_community_overview", "Active People Git Community Overview", c.ActivePeopleGitCommunityOverview),
+		intMetric("active_people_issues_community_overview", "Active People Issues Community Overview", c.ActivePeopleIssuesCommunityOverview),
+		intMetric("active_people_patches_community_overview", "Active People Patches Community Overview", c.ActivePeoplePatchesCommunityOverview),
+		intMetric("onboardings_git_community_overview", "Onboardings Git Community Overview", c.OnboardingsGitCommunityOverview),
+		intMetric("onboardings_issues_community_overview", "Onboardings Issues Community Overview", c.OnboardingsIssuesCommunityOverview),
+		intMetric("onboardings_patches_community_overview", "Onboardings Patches Community Overview", c.OnboardingsPatchesCommunityOverview),
 	}
 }
 
@@ -104,58 +130,73 @@ func (c *Community) Data() [][]string {
 // Overview is a struct that represents the overview tab of the metrics endpoint.
 // The token 'YOY' stands for 'Year-Over-Year'.
 type Overview struct {
-	CommitsOverview                          int     `json:"commits_overview"`
-	IssuesOverview                           int     `json:"issues_overview"`
-	ReviewsOverview                          int     `json:"reviews_overview"`
-	CommitsLastYearOverview                  int     `json:"commits_last_year_overview"`
-	IssuesLastYearOverview                   int     `json:"issues_last_year_overview"`
-	ReviewsLastYearOverview                  int     `json:"reviews_last_year_overview"`
-	CommitsYoyOverview                       float64 `json:"commits_yoy_overview"`
-	IssuesYoyOverview                        float64 `json:"issues_yoy_overview"`
-	ReviewsYoyOverview                       float64 `json:"reviews_yoy_overview"`
-	CommitAuthorsOverview                    int     `json:"commit_authors_overview"`
-	IssueSubmittersOverview                  int     `json:"issue_submitters_overview"`
-	ReviewSubmittersOverview                 int     `json:"review_submitters_overview"`
-	CommitAuthorsLastYearOverview            int     `json:"commit_authors_last_year_overview"`
-	IssueSubmittersLastYearOverview          int     `json:"issue_submitters_last_year_overview"`
-	ReviewSubmittersLastYearOverview         int     `json:"review_submitters_last_year_overview"`
-	CommitAuthorsYoyOverview                 float64 `json:"commit_authors_yoy_overview"`
-	IssueSubmittersYoyOverview               float64 `json:"issue_submitters_yoy_overview"`
-	ReviewSubmittersYoyOverview              float64 `json:"review_submitters_yoy_overview"`
-	IssuesMedianTimeToCloseOverview          float64 `json:"issues_median_time_to_close_overview"`
-	ReviewsMedianTimeToCloseOverview         float64 `json:"reviews_median_time_to_close_overview"`
-	IssuesMedianTimeToCloseLastYearOverview  float64 `json:"issues_median_time_to_close_last_year_overview"`
-	ReviewsMedianTimeToCloseLastYearOverview float64 `json:"reviews_median_time_to_close_last_year_overview"`
-	IssuesMedianTimeToCloseYoyOverview       float64 `json:"issues_median_time_to_close_yoy_overview"`
-	ReviewsMedianTimeToCloseYoyOverview      float64 `json:"reviews_median_time_to_close_yoy_overview"`
+	CommitsOverview                          *int     `json:"commits_overview"`
+	IssuesOverview                           *int     `json:"issues_overview"`
+	ReviewsOverview                          *int     `json:"reviews_overview"`
+	CommitsLastYearOverview                  *int     `json:"commits_last_year_overview"`
+	IssuesLastYearOverview                   *int     `json:"issues_last_year_overview"`
+	ReviewsLastYearOverview                  *int     `json:"reviews_last_year_overview"`
+	CommitsYoyOverview                       *float64 `json:"commits_yoy_overview"`
+	IssuesYoyOverview                        *float64 `json:"issues_yoy_overview"`
+	ReviewsYoyOverview                       *float64 `json:"reviews_yoy_overview"`
+	CommitAuthorsOverview                    *int     `json:"commit_authors_overview"`
+	IssueSubmittersOverview                  *int     `json:"issue_submitters_overview"`
+	ReviewSubmittersOverview                 *int     `json:"review_submitters_overview"`
+	CommitAuthorsLastYearOverview            *int     `json:"commit_authors_last_year_overview"`
+	IssueSubmittersLastYearOverview          *int     `json:"issue_submitters_last_year_overview"`
+	ReviewSubmittersLastYearOverview         *int     `json:"review_submitters_last_year_overview"`
+	CommitAuthorsYoyOverview                 *float64 `json:"commit_authors_yoy_overview"`
+	IssueSubmittersYoyOverview               *float64 `json:"issue_submitters_yoy_overview"`
+	ReviewSubmittersYoyOverview              *float64 `json:"review_submitters_yoy_overview"`
+	IssuesMedianTimeToCloseOverview          *float64 `json:"issues_median_time_to_close_overview"`
+	ReviewsMedianTimeToCloseOverview         *float64 `json:"reviews_median_time_to_close_overview"`
+	IssuesMedianTimeToCloseLastYearOverview  *float64 `json:"issues_median_time_to_close_last_year_overview"`
+	ReviewsMedianTimeToCloseLastYearOverview *float64 `json:"reviews_median_time_to_close_last_year_overview"`
+	IssuesMedianTimeToCloseYoyOverview       *float64 `json:"issues_median_time_to_close_yoy_overview"`
+	ReviewsMedianTimeToCloseYoyOverview      *float64 `json:"reviews_median_time_to_close_yoy_overview"`
+	// Extra keeps the fields of the response not modelled by the struct
+	Extra map[string]any `json:"-"`
+}
+
+func (o Overview) MarshalJSON() ([]byte, error) {
+	type alias Overview
+	return marshalWithExtra(alias(o), o.Extra)
+}
+
+func (o *Overview) setExtra(extra map[string]any) {
+	o.Extra = extra
 }
 
 func (o *Overview) Data() [][]string {
-	return [][]string{
-		{"Commits Overview", fmt.Sprintf("%d", o.CommitsOverview)},
-		{"Issues Overview", fmt.Sprintf("%d", o.IssuesOverview)},
-		{"Reviews Overview", fmt.Sprintf("%d", o.ReviewsOverview)},
-		{"Commits Last Year Overview", fmt.Sprintf("%d", o.CommitsLastYearOverview)},
-		{"Issues Last Year Overview", fmt.Sprintf("%d", o.IssuesLastYearOverview)},
-		{"Reviews Last Year Overview", fmt.Sprintf("%d", o.ReviewsLastYearOverview)},
-		{"Commits YoY Overview", fmt.Sprintf("%.2f", o.CommitsYoyOverview)},
-		{"Issues YoY Overview", fmt.Sprintf("%.2f", o.IssuesYoyOverview)},
-		{"Reviews YoY Overview", fmt.Sprintf("%.2f", o.ReviewsYoyOverview)},
-		{"Commit Authors Overview", fmt.Sprintf("%d", o.CommitAuthorsOverview)},
-		{"Issue Submitters Overview", fmt.Sprintf("%d", o.IssueSubmittersOverview)},
-		{"Review Submitters Overview", fmt.Sprintf("%d", o.ReviewSubmittersOverview)},
-		{"Commit Authors Last Year Overview", fmt.Sprintf("%d", o.CommitAuthorsLastYearOverview)},
-		{"Issue Submitters Last Year Overview", fmt.Sprintf("%d", o.IssueSubmittersLastYearOverview)},
-		{"Review Submitters Last Year Overview", fmt.Sprintf("%d", o.ReviewSubmittersLastYearOverview)},
-		{"Commit Authors YoY Overview", fmt.Sprintf("%.2f", o.CommitAuthorsYoyOverview)},
-		{"Issue Submitters YoY Overview", fmt.Sprintf("%.2f", o.IssueSubmittersYoyOverview)},
-		{"Review Submitters YoY Overview", fmt.Sprintf("%.2f", o.ReviewSubmittersYoyOverview)},
-		{"Issues Median Time To Close Overview", fmt.Sprintf("%.2f", o.IssuesMedianTimeToCloseOverview)},
-		{"Reviews Median Time To Close Overview", fmt.Sprintf("%.2f", o.ReviewsMedianTimeToCloseOverview)},
-		{"Issues Median Time To Close Last Year Overview", fmt.Sprintf("%.2f", o.IssuesMedianTimeToCloseLastYearOverview)},
-		{"Reviews Median Time To Close Last Year Overview", fmt.Sprintf("%.2f", o.ReviewsMedianTimeToCloseLastYearOverview)},
-		{"Issues Median Time To Close YoY Overview", fmt.Sprintf("%.2f", o.IssuesMedianTimeToCloseYoyOverview)},
-		{"Reviews Median Time To Close YoY Overview", fmt.Sprintf("%.2f", o.ReviewsMedianTimeToCloseYoyOverview)},
+	return rows(o.Metrics())
+}
+
+func (o *Overview) Metrics() []Metric {
+	return []Metric{
+		intMetric("commits_overview", "Commits Overview", o.CommitsOverview),
+		intMetric("issues_overview", "Issues Overview", o.IssuesOverview),
+		intMetric("reviews_overview", "Reviews Overview", o.ReviewsOverview),
+		intMetric("commits_last_year_overview", "Commits Last Year Overview", o.CommitsLastYearOverview),
+		intMetric("issues_last_year_overview", "Issues Last Year Overview", o.IssuesLastYearOverview),
+		intMetric("reviews_last_year_overview", "Reviews Last Year Overview", o.ReviewsLastYearOverview),
+		floatMetric("commits_yoy_overview", "Commits YoY Overview", o.CommitsYoyOverview),
+		floatMetric("issues_yoy_overview", "Issues YoY Overview", o.IssuesYoyOverview),
+		floatMetric("reviews_yoy_overview", "Reviews YoY Overview", o.ReviewsYoyOverview),
+		intMetric("commit_authors_overview", "Commit Authors Overview", o.CommitAuthorsOverview),
+		intMetric("issue_submitters_overview", "Issue Submitters Overview", o.IssueSubmittersOverview),
+		intMetric("review_submitters_overview", "Review Submitters Overview", o.ReviewSubmittersOverview),
+		intMetric("commit_authors_last_year_overview", "Commit Authors Last Year Overview", o.CommitAuthorsLastYearOverview),
+		intMetric("issue_submitters_last_year_overview", "Issue Submitters Last Year Overview", o.IssueSubmittersLastYearOverview),
+		intMetric("review_submitters_last_year_overview", "Review Submitters Last Year Overview", o.ReviewSubmittersLastYearOverview),
+		floatMetric("commit_authors_yoy_overview", "Commit Authors YoY Overview", o.CommitAuthorsYoyOverview),
+		floatMetric("issue_submitters_yoy_overview", "Issue Submitters YoY Overview", o.IssueSubmittersYoyOverview),
+		floatMetric("review_submitters_yoy_overview", "Review Submitters YoY Overview", o.ReviewSubmittersYoyOverview),
+		floatMetric("issues_median_time_to_close_overview", "Issues Median Time To Close Overview", o.IssuesMedianTimeToCloseOverview),
+		floatMetric("reviews_median_time_to_close_overview", "Reviews Median Time To Close Overview", o.ReviewsMedianTimeToCloseOverview),
+		floatMetric("issues_median_time_to_close_last_year_overview", "Issues Median Time To Close Last Year Overview", o.IssuesMedianTimeToCloseLastYearOverview),
+		floatMetric("reviews_median_time_to_close_last_year_overview", "Reviews Median Time To Close Last Year Overview", o.ReviewsMedianTimeToCloseLastYearOverview),
+		floatMetric("issues_median_time_to_close_yoy_overview", "Issues Median Time To Close YoY Overview", o.IssuesMedianTimeToCloseYoyOverview),
+		floatMetric("reviews_median_time_to_close_yoy_overview", "Reviews Median Time To Close YoY Overview", o.ReviewsMedianTimeToCloseYoyOverview),
 	}
 }
 
@@ -170,21 +211,36 @@ func (o *Overview) Data() [][]string {
 	}
 */
 type Performance struct {
-	IssuesTimeOpenAveragePerformanceOverview  float64 `json:"issues_time_open_average_performance_overview"`
-	IssuesTimeOpenMedianPerformanceOverview   float64 `json:"issues_time_open_median_performance_overview"`
-	OpenIssuesPerformanceOverview             int     `json:"open_issues_performance_overview"`
-	ReviewsTimeOpenAveragePerformanceOverview float64 `json:"reviews_time_open_average_performance_overview"`
-	ReviewsTimeOpenMedianPerformanceOverview  float64 `json:"reviews_time_open_median_performance_overview"`
-	OpenReviewsPerformanceOverview            int     `json:"open_reviews_performance_overview"`
+	IssuesTimeOpenAveragePerformanceOverview  *float64 `json:"issues_time_open_average_performance_overview"`
+	IssuesTimeOpenMedianPerformanceOverview   *float64 `json:"issues_time_open_median_performance_overview"`
+	OpenIssuesPerformanceOverview             *int     `json:"open_issues_performance_overview"`
+	ReviewsTimeOpenAveragePerformanceOverview *float64 `json:"reviews_time_open_average_performance_overview"`
+	ReviewsTimeOpenMedianPerformanceOverview  *float64 `json:"reviews_time_open_median_performance_overview"`
+	OpenReviewsPerformanceOverview            *int     `json:"open_reviews_performance_overview"`
+	// Extra keeps the fields of the response not modelled by the struct
+	Extra map[string]any `json:"-"`
+}
+
+func (p Performance) MarshalJSON() ([]byte, error) {
+	type alias Performance
+	return marshalWithExtra(alias(p), p.Extra)
+}
+
+func (p *Performance) setExtra(extra map[string]any) {
+	p.Extra = extra
 }
 
 func (p *Performance) Data() [][]string {
-	return [][]string{
-		{"Issues Time Open Average Performance Overview", fmt.Sprintf("%.2f", p.IssuesTimeOpenAveragePerformanceOverview)},
-		{"Issues Time Open Median Performance Overview", fmt.Sprintf("%.2f", p.IssuesTimeOpenMedianPerformanceOverview)},
-		{"Open Issues Performance Overview", fmt.Sprintf("%d", p.OpenIssuesPerformanceOverview)},
-		{"Reviews Time Open Average Performance Overview", fmt.Sprintf("%.2f", p.ReviewsTimeOpenAveragePerformanceOverview)},
-		{"Reviews Time Open Median Performance Overview", fmt.Sprintf("%.2f", p.ReviewsTimeOpenMedianPerformanceOverview)},
-		{"Open Reviews Performance Overview", fmt.Sprintf("%d", p.OpenReviewsPerformanceOverview)},
+	return rows(p.Metrics())
+}
+
+func (p *Performance) Metrics() []Metric {
+	return []Metric{
+		floatMetric("issues_time_open_average_performance_overview", "Issues Time Open Average Performance Overview", p.IssuesTimeOpenAveragePerformanceOverview),
+		floatMetric("issues_time_open_median_performance_overview", "Issues Time Open Median Performance Overview", p.IssuesTimeOpenMedianPerformanceOverview),
+		intMetric("open_issues_performance_overview", "Open Issues Performance Overview", p.OpenIssuesPerformanceOverview),
+		floatMetric("reviews_time_open_average_performance_overview", "Reviews Time Open Average Performance Overview", p.ReviewsTimeOpenAveragePerformanceOverview),
+		floatMetric("reviews_time_open_median_performance_overview", "Reviews Time Open Median Performance Overview", p.ReviewsTimeOpenMedianPerformanceOverview),
+		intMetric("open_reviews_performance_overview", "Open Reviews Performance Overview", p.OpenReviewsPerformanceOverview),
 	}
 }
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+)
+
+var detectors []string
+var window int
+var zscoreThreshold float64
+var pctChangeThreshold float64
+var madThreshold float64
+var minSeverity string
+
+func init() {
+	addSelectionFlags(cmdAnomalies)
+	cmdAnomalies.Flags().StringVarP(&tab, "tab", "T", "", "The tab of the metrics. Default is all the known tabs.")
+	cmdAnomalies.Flags().StringSliceVarP(&metricKeys, "metric", "m", []string{}, "The JSON keys of the metrics. Default is all the metrics of the tabs.")
+	addRangeFlags(cmdAnomalies)
+	cmdAnomalies.Flags().StringVarP(&interval, "interval", "i", "", "Fetch a time series of the period split into intervals, instead of using the stored snapshots. Possible values are: week, month, quarter and year. Default is using the store.")
+	cmdAnomalies.Flags().StringVarP(&from, "from", "f", period.DefaultFrom, "The start date of the fetched time series, as YYYY-MM-DD or a relative expression. Only used with --interval. Default is one year ago.")
+	cmdAnomalies.Flags().StringVarP(&to, "to", "t", "", "The end date of the fetched time series. Only used with --interval. Default is today, or the end of the --from range.")
+	cmdAnomalies.Flags().StringSliceVarP(&repoURLs, "repo-url", "r", []string{}, "The repository URLs to fetch metrics. Only used with --interval. Default is empty.")
+	cmdAnomalies.Flags().StringSliceVar(&detectors, "detector", []string{"zscore", "pct-change", "mad"}, "The detectors to apply. Possible values are: zscore, pct-change and mad. Default is all of them.")
+	cmdAnomalies.Flags().IntVar(&window, "window", 8, "The number of previous values the zscore and mad detectors compare each point with. Default is 8.")
+	cmdAnomalies.Flags().Float64Var(&zscoreThreshold, "zscore-threshold", 3, "The absolute z-score flagging a point. Default is 3.")
+	cmdAnomalies.Flags().Float64Var(&pctChangeThreshold, "pct-change-threshold", 50, "The absolute percentage change from the previous value flagging a point. Default is 50.")
+	cmdAnomalies.Flags().Float64Var(&madThreshold, "mad-threshold", 3.5, "The absolute modified z-score, based on the median absolute deviation, flagging a point. Default is 3.5.")
+	cmdAnomalies.Flags().StringVar(&minSeverity, "min-severity", cauldron.Low, "The minimum severity of the anomalies to print. Possible values are: low, medium and high. Default is low.")
+	cmdAnomalies.Flags().StringVarP(&format, "format", "F", "console", "The format to output the anomalies. Possible values are: console, json, markdown, csv and html. Default is console.")
+
+	rootCmd.AddCommand(cmdAnomalies)
+}
+
+var cmdAnomalies = &cobra.Command{
+	Use:   "anomalies",
+	Short: "Detect anomalies in the history of the metrics",
+	Long: `Detect anomalies in the history of the metrics, recorded in the store with
+				  --store or fetched as a time series with --interval, applying z-score,
+				  percentage change and median absolute deviation detectors. The flagged
+				  points are printed with their severity, from the most severe.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := anomaliesRun(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func anomaliesRun(cmd *cobra.Command) error {
+	ds, err := cauldron.ParseDetectors(detectors, window, zscoreThreshold, pctChangeThreshold, madThreshold)
+	if err != nil {
+		return err
+	}
+
+	severity, err := cauldron.ParseSeverity(minSeverity)
+	if err != nil {
+		return err
+	}
+
+	var trends []cauldron.Trend
+	if interval != "" {
+		trends, err = fetchedTrends()
+	} else {
+		trends, err = storedTrends(cmd)
+	}
+	if err != nil {
+		return err
+	}
+
+	anomalies := cauldron.DetectAnomalies(trends, ds, severity)
+
+	return cauldron.NewAnomalyReport(anomalies).Write(os.Stdout, format)
+}
+
+// storedTrends returns the trends of the metrics of the known tabs, or the
+// --tab one, in the store.
+func storedTrends(cmd *cobra.Command) ([]cauldron.Trend, error) {
+	snapshots, err := storedSnapshots(cmd, knownTabs)
+	if err != nil {
+		return nil, err
+	}
+
+	return cauldron.NewTrends(snapshots, metricKeys)
+}
+
+// fetchedTrends fetches the time series of the selected projects, split by
+// the --interval flag into complete windows, returning the trends of their
+// metrics.
+func fetchedTrends() ([]cauldron.Trend, error) {
+	i, err := period.ParseInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	pd, err := period.Parse(from, to, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := selectProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := map[string]bool{}
+	for _, k := range metricKeys {
+		wanted[k] = true
+	}
+
+	// the partial windows at the ends of the period would look like anomalies
+	windows := pd.SplitComplete(i)
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("error splitting the period %s: it has no complete %s", pd, i)
+	}
+
+	tabs := tabsFor(tab)
+
+	trends := []cauldron.Trend{}
+	for _, p := range projects {
+		series, err := fetchSeries(p, windows, tabs, repoURLs)
+		if err != nil {
+			return nil, err
+		}
+
+		for t, tab := range tabs {
+			for _, trend := range cauldron.TrendsFromSeries(p, tab, series[t]) {
+				if len(wanted) == 0 || wanted[trend.Key] {
+					trends = append(trends, trend)
+				}
+			}
+		}
+	}
+
+	return trends, nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+)
+
+// The exit codes of the check command, besides 1 for the errors.
+const (
+	exitFail = 2
+	exitWarn = 3
+)
+
+var failOnWarn bool
+
+func init() {
+	addSelectionFlags(cmdCheck)
+	cmdCheck.Flags().StringVarP(&from, "from", "f", period.DefaultFrom, "The start date to fetch metrics, as YYYY-MM-DD or a relative expression. Default is one year ago.")
+	cmdCheck.Flags().StringVarP(&to, "to", "t", "", "The end date to fetch metrics, as YYYY-MM-DD or a relative expression. Default is today, or the end of the --from range.")
+	cmdCheck.Flags().StringVarP(&tab, "tab", "T", "", "The tab to fetch metrics. Default is all the known tabs.")
+	cmdCheck.Flags().StringSliceVarP(&repoURLs, "repo-url", "r", []string{}, "The repository URLs to fetch metrics. Default is empty.")
+	cmdCheck.Flags().BoolVar(&failOnWarn, "fail-on-warn", false, "Exit with code 3 if any rule warns and none fails. Default is false.")
+	cmdCheck.Flags().StringVarP(&format, "format", "F", "console", "The format to output the results. Possible values are: console, json, markdown, csv and html. Default is console.")
+
+	rootCmd.AddCommand(cmdCheck)
+}
+
+var cmdCheck = &cobra.Command{
+	Use:   "check",
+	Short: "Check the metrics against the rules of the configuration file",
+	Long: `Fetch the metrics of the projects and check them against the rules of the
+				  configuration file, printing pass, warn or fail for each rule and
+				  project. It exits with code 2 if any rule fails, with code 3 if any
+				  rule warns with --fail-on-warn, and with code 1 on errors.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		projects, err := selectProjects()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		status, err := checkRun(cmd.Flags(), projects, cfg.Rules, repoURLs)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		switch {
+		case status == rule.Fail:
+			os.Exit(exitFail)
+		case status == rule.Warn && failOnWarn:
+			os.Exit(exitWarn)
+		}
+	},
+}
+
+// checkRun evaluates the rules for each project, printing the results, and
+// returns the most severe status.
+func checkRun(flags *pflag.FlagSet, projects []project.Project, rules []rule.Rule, repoURLs []string) (string, error) {
+	if len(rules) == 0 {
+		return "", fmt.Errorf("there are no rules to check: configure them in the rules of the configuration file")
+	}
+
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	now := time.Now()
+
+	results := []cauldron.CheckResult{}
+	for _, p := range projects {
+		s, err := projectSettings(flags, p, now)
+		if err != nil {
+			return "", err
+		}
+
+		// the tabs reporting the metrics of the rules, which may be derived,
+		// computed or not in the tabs of the project
+		keys := []string{}
+		for _, r := range rules {
+			if r.Applies(p) {
+				keys = append(keys, r.Keys()...)
+			}
+		}
+
+		ruleTabs, err := tabsOf(keys)
+		if err != nil {
+			return "", fmt.Errorf("project %s (%d): %w", p.Name, p.ID, err)
+		}
+
+		tabs := append([]string{}, s.tabs...)
+		for _, t := range ruleTabs {
+			if !slices.Contains(tabs, t) {
+				tabs = append(tabs, t)
+			}
+		}
+
+		fetched, err := fetchTabs(p, s.period, tabs, repoURLs)
+		if err != nil {
+			return "", err
+		}
+
+		printables := make([]cauldron.Printable, len(fetched))
+		for i, result := range fetched {
+			printables[i] = result.Printable
+		}
+
+		projectResults, err := cauldron.Check(p, rules, printables)
+		if err != nil {
+			return "", err
+		}
+
+		results = append(results, projectResults...)
+	}
+
+	if err := cauldron.NewCheckReport(results).Write(os.Stdout, format); err != nil {
+		return "", err
+	}
+
+	return cauldron.CheckStatus(results), nil
+}
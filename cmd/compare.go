@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+var fromA string
+var toA string
+var fromB string
+var toB string
+
+func init() {
+	addSelectionFlags(cmdCompare)
+	cmdCompare.Flags().StringVar(&fromA, "from-a", "", "The start date of the first period, as YYYY-MM-DD or a relative expression. Required.")
+	cmdCompare.Flags().StringVar(&toA, "to-a", "", "The end date of the first period. Default is today, or the end of the --from-a range.")
+	cmdCompare.Flags().StringVar(&fromB, "from-b", "", "The start date of the second period, as YYYY-MM-DD or a relative expression. Required.")
+	cmdCompare.Flags().StringVar(&toB, "to-b", "", "The end date of the second period. Default is today, or the end of the --from-b range.")
+	cmdCompare.Flags().StringVarP(&tab, "tab", "T", "", "The tab to compare. Default is all the known tabs.")
+	cmdCompare.Flags().StringVarP(&format, "format", "F", "console", "The format to output the comparison. Possible values are: console, json, markdown, csv and html. Default is console.")
+	cmdCompare.Flags().StringSliceVarP(&repoURLs, "repo-url", "r", []string{}, "The repository URLs to fetch metrics. Default is empty.")
+
+	_ = cmdCompare.MarkFlagRequired("from-a")
+	_ = cmdCompare.MarkFlagRequired("from-b")
+
+	rootCmd.AddCommand(cmdCompare)
+}
+
+var cmdCompare = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare the metrics of two periods",
+	Long: `Compare the metrics of two arbitrary periods, A and B, e.g. two quarters or
+				  before and after a release. For each metric, it prints the values of
+				  both periods, the absolute delta and the percentage change, highlighting
+				  the metrics that got worse.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		now := time.Now()
+
+		a, err := period.Parse(fromA, toA, now)
+		if err != nil {
+			fmt.Println("period A:", err)
+			os.Exit(1)
+		}
+
+		b, err := period.Parse(fromB, toB, now)
+		if err != nil {
+			fmt.Println("period B:", err)
+			os.Exit(1)
+		}
+
+		projects, err := selectProjects()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if err := compareRun(projects, a, b, tab, repoURLs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func compareRun(projects []project.Project, a period.Period, b period.Period, tab string, repoURLs []string) error {
+	tabs := tabsFor(tab)
+
+	reports := []cauldron.Report{}
+	for _, p := range projects {
+		resultsA, err := fetchTabs(p, a, tabs, repoURLs)
+		if err != nil {
+			return err
+		}
+
+		resultsB, err := fetchTabs(p, b, tabs, repoURLs)
+		if err != nil {
+			return err
+		}
+
+		for i, t := range tabs {
+			comparisons := cauldron.Compare(resultsA[i].Printable, resultsB[i].Printable)
+			reports = append(reports, cauldron.NewComparisonReport(p, a, b, t, comparisons))
+		}
+	}
+
+	return cauldron.MergeReports(reports...).Write(os.Stdout, format)
+}
@@ -0,0 +1,398 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/config"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+var force bool
+var sources bool
+var newProject project.Project
+var newLabels []string
+
+func init() {
+	cmdConfigInit.Flags().BoolVar(&force, "force", false, "Overwrite the configuration file if it exists. Default is false.")
+
+	cmdConfigAddProject.Flags().IntVar(&newProject.ID, "id", 0, "The ID of the Cauldron project. Required.")
+	cmdConfigAddProject.Flags().StringVar(&newProject.Name, "name", "", "The name of the project. Default is empty.")
+	cmdConfigAddProject.Flags().StringSliceVar(&newProject.RepoURL, "repo-url", []string{}, "The repository URLs of the project. Default is empty.")
+	cmdConfigAddProject.Flags().StringSliceVar(&newProject.Tabs, "tab", []string{}, "The tabs to fetch for the project. Default is all the known tabs.")
+	cmdConfigAddProject.Flags().StringVar(&newProject.From, "from", "", "The default start date of the project, with the same expressions as the --from flag of metrics. Default is empty.")
+	cmdConfigAddProject.Flags().StringVar(&newProject.To, "to", "", "The default end date of the project, with the same expressions as the --to flag of metrics. Default is empty.")
+	cmdConfigAddProject.Flags().StringArrayVar(&newLabels, "label", []string{}, "A key=value label of the project. It can be repeated.")
+	_ = cmdConfigAddProject.MarkFlagRequired("id")
+
+	cmdConfigView.Flags().BoolVar(&sources, "sources", false, "Print the source of each setting: a flag, an environment variable, the configuration file or a default. Default is false.")
+
+	cmdConfig.AddCommand(cmdConfigValidate)
+	cmdConfig.AddCommand(cmdConfigInit)
+	cmdConfig.AddCommand(cmdConfigAddProject)
+	cmdConfig.AddCommand(cmdConfigRemoveProject)
+	cmdConfig.AddCommand(cmdConfigSet)
+	cmdConfig.AddCommand(cmdConfigView)
+	rootCmd.AddCommand(cmdConfig)
+}
+
+var cmdConfig = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the configuration file",
+	Long:  `Manage the configuration file of cauldrongo.`,
+	// the configuration file may not be decodable, so don't fail before running the subcommands
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := applyGlobals(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var cmdConfigValidate = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration file",
+	Long: `Strictly validate the configuration file, reporting unknown keys, values
+				  of the wrong type, duplicate projects, malformed repository URLs,
+				  invalid dates and groups with unknown projects, with their line numbers.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateConfig(); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if len(cfgFiles) > 0 {
+			fmt.Printf("%s is valid\n", strings.Join(cfgFiles, ", "))
+		}
+	},
+}
+
+var cmdConfigInit = &cobra.Command{
+	Use:   "init",
+	Short: "Create a configuration file",
+	Long: `Create a commented configuration file, with a sample project and group,
+				  at the path of the --config flag, or in the working directory.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := cfgFile
+		if path == "" {
+			path = DefaultConfigFile
+		}
+
+		if _, err := os.Stat(path); err == nil && !force {
+			fmt.Printf("%s already exists, pass --force to overwrite it\n", path)
+			os.Exit(1)
+		}
+
+		if err := os.WriteFile(path, []byte(config.Template), 0o644); err != nil {
+			fmt.Println("error writing the configuration file:", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s created\n", path)
+	},
+}
+
+var cmdConfigAddProject = &cobra.Command{
+	Use:   "add-project",
+	Short: "Add a project to the configuration file",
+	Long:  `Add a project to the configuration file, keeping its comments and the order of its keys.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := editConfig(func(f *config.File) error {
+			if len(newLabels) > 0 {
+				newProject.Labels = map[string]string{}
+			}
+
+			for _, l := range newLabels {
+				key, value, ok := strings.Cut(l, "=")
+				if !ok || key == "" {
+					return fmt.Errorf("invalid label %q: expected key=value", l)
+				}
+
+				newProject.Labels[key] = value
+			}
+
+			return f.AddProject(newProject)
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var cmdConfigRemoveProject = &cobra.Command{
+	Use:   "remove-project <name|id>",
+	Short: "Remove a project from the configuration file",
+	Long: `Remove a project, identified by name or ID, from the configuration file
+				  and from the groups referencing it, keeping the comments and the order
+				  of the keys.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := editConfig(func(f *config.File) error {
+			return f.RemoveProject(args[0])
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var cmdConfigSet = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a value of the configuration file",
+	Long: `Set a value of the configuration file, keeping its comments and the order
+				  of its keys. The key is a dotted path, where the projects and the groups
+				  are identified by their name or ID, e.g. projects.testcontainers-go.from.
+				  The value is parsed as YAML, so lists are written as [a, b].`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := editConfig(func(f *config.File) error {
+			return f.Set(args[0], args[1])
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var cmdConfigView = &cobra.Command{
+	Use:   "view",
+	Short: "Print the effective configuration",
+	Long: `Print the effective configuration, as read from the configuration file,
+				  with the environment variables overriding the global settings and the
+				  defaults of the unset settings of the projects.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if cfgErr != nil {
+			fmt.Println("Can't unmarshal projects:", cfgErr)
+			os.Exit(1)
+		}
+
+		if sources {
+			if err := viewSources(cmd.Flags()); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			return
+		}
+
+		bs, err := config.Marshal(withDefaults(cfg))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("# %s\n%s", configFile(), bs)
+	},
+}
+
+// configFile returns the path of the configuration file in use, with the
+// highest priority, which is the one edited.
+func configFile() string {
+	if len(cfgFiles) > 0 {
+		return cfgFiles[0]
+	}
+
+	if cfgFile != "" {
+		return cfgFile
+	}
+
+	return DefaultConfigFile
+}
+
+// validateConfig validates the configuration files in use, printing the
+// issues found, and failing if there is any. The projects of all the files
+// are valid members of the groups of any of them.
+func validateConfig() error {
+	paths := cfgFiles
+	if len(paths) == 0 {
+		paths = []string{configFile()}
+	}
+
+	count := 0
+	for _, path := range paths {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading the configuration file: %w", err)
+		}
+
+		issues, err := config.Validate(bs, time.Now(), cfg.Projects)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		for _, issue := range issues {
+			fmt.Printf("%s:%d: %s\n", path, issue.Line, issue.Message)
+		}
+
+		count += len(issues)
+	}
+
+	if cfgErr != nil {
+		return cfgErr
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%s is not valid: %d issue(s) found", strings.Join(paths, ", "), count)
+	}
+
+	return nil
+}
+
+// editConfig applies an edit to the configuration file in use, writing it
+// back only if the result is valid.
+func editConfig(edit func(f *config.File) error) error {
+	path := configFile()
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s doesn't exist, create it with: cauldrongo config init", path)
+	} else if err != nil {
+		return fmt.Errorf("error reading the configuration file: %w", err)
+	}
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading the configuration file: %w", err)
+	}
+
+	f, err := config.ParseFile(bs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := edit(f); err != nil {
+		return err
+	}
+
+	edited, err := f.Bytes()
+	if err != nil {
+		return err
+	}
+
+	issues, err := config.Validate(edited, time.Now(), cfg.Projects)
+	if err != nil {
+		return err
+	}
+
+	if len(issues) > 0 {
+		messages := make([]string, len(issues))
+		for i, issue := range issues {
+			messages[i] = issue.Message
+		}
+
+		return fmt.Errorf("the edited configuration is not valid, %s is unchanged: %s", path, strings.Join(messages, "; "))
+	}
+
+	if err := os.WriteFile(path, edited, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("error writing the configuration file: %w", err)
+	}
+
+	return nil
+}
+
+// withDefaults returns a copy of the configuration with the effective global
+// settings, and the defaults of the flags in the unset settings of the projects.
+func withDefaults(c config.Config) config.Config {
+	effective := c.WithDefaults(project.Project{From: period.DefaultFrom, To: "today", Tabs: knownTabs, Format: "console"})
+	effective.BaseURL = baseURL
+	effective.Token = mask(token)
+	effective.Concurrency = concurrency
+
+	return effective
+}
+
+// viewSources prints the effective value and the source of the global
+// settings, of the flags of the metrics command and of every other setting
+// of the configuration file.
+func viewSources(flags *pflag.FlagSet) error {
+	table := cauldron.Table{
+		Title:   "Configuration: " + configFile(),
+		Headers: []string{"Setting", "Value", "Source"},
+		Rows: [][]string{
+			{"config", strings.Join(cfgFiles, ", "), source(flags, "config", false)},
+			{"base-url", baseURL, source(flags, "base-url", cfg.BaseURL != "")},
+			{"token", mask(token), source(flags, "token", cfg.Token != "")},
+			{"concurrency", strconv.Itoa(concurrency), source(flags, "concurrency", cfg.Concurrency != 0)},
+			{"verbose", strconv.FormatBool(verbose), source(flags, "verbose", false)},
+		},
+	}
+
+	// the flags of the metrics command are only set from the environment here
+	if err := applyEnv(cmdMetrics.Flags()); err != nil {
+		return err
+	}
+
+	cmdMetrics.Flags().VisitAll(func(f *pflag.Flag) {
+		table.Rows = append(table.Rows, []string{"metrics --" + f.Name, f.Value.String(), source(cmdMetrics.Flags(), f.Name, false)})
+	})
+
+	// the raw values and their files, by path, with the highest priority
+	type rawValue struct{ file, value string }
+	raw := map[string]rawValue{}
+	for i := len(cfgFiles) - 1; i >= 0; i-- {
+		bs, err := os.ReadFile(cfgFiles[i])
+		if err != nil {
+			return fmt.Errorf("error reading the configuration file: %w", err)
+		}
+
+		leaves, err := config.Leaves(bs)
+		if err != nil {
+			return err
+		}
+
+		for _, l := range leaves {
+			raw[l.Path] = rawValue{file: cfgFiles[i], value: l.Value}
+		}
+	}
+
+	bs, err := config.Marshal(withDefaults(cfg))
+	if err != nil {
+		return err
+	}
+
+	leaves, err := config.Leaves(bs)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range leaves {
+		// the global settings are already listed, with their flags
+		if l.Path == "base_url" || l.Path == "token" || l.Path == "concurrency" {
+			continue
+		}
+
+		src := "default"
+		if r, ok := raw[l.Path]; ok {
+			src = "config file " + r.file
+
+			if vars := config.Variables(r.value); len(vars) > 0 {
+				src += ", env " + strings.Join(vars, ", ")
+			}
+		}
+
+		table.Rows = append(table.Rows, []string{l.Path, l.Value, src})
+	}
+
+	return cauldron.Report{Tables: []cauldron.Table{table}}.Write(os.Stdout, "console")
+}
+
+// mask hides a secret, showing only whether it is set.
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return "****"
+}
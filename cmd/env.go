@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/mdelapenya/cauldrongo/config"
+)
+
+// envFlags are the flags set from their environment variables.
+var envFlags = map[string]bool{}
+
+// applyEnv sets the flags not passed in the command line from their
+// CAULDRONGO_* environment variables, e.g. CAULDRONGO_REPO_URL for --repo-url.
+func applyEnv(flags *pflag.FlagSet) error {
+	var err error
+
+	flags.VisitAll(func(f *pflag.Flag) {
+		if err != nil || f.Changed || f.Name == "help" {
+			return
+		}
+
+		// prefixed, not to collide with the keys of the configuration file, e.g. token
+		key := "env-" + f.Name
+		if bindErr := viper.BindEnv(key, config.EnvVar(f.Name)); bindErr != nil {
+			err = bindErr
+			return
+		}
+
+		if !viper.IsSet(key) {
+			return
+		}
+
+		if setErr := flags.Set(f.Name, viper.GetString(key)); setErr != nil {
+			err = fmt.Errorf("invalid value of %s: %w", config.EnvVar(f.Name), setErr)
+			return
+		}
+
+		envFlags[f.Name] = true
+	})
+
+	return err
+}
+
+// resolveGlobals resolves the global settings not set by a flag or an
+// environment variable from the configuration file.
+func resolveGlobals(flags *pflag.FlagSet) error {
+	if !flags.Changed("base-url") && cfg.BaseURL != "" {
+		baseURL = cfg.BaseURL
+	}
+
+	if !flags.Changed("token") && cfg.Token != "" {
+		token = cfg.Token
+	}
+
+	if !flags.Changed("concurrency") && cfg.Concurrency != 0 {
+		concurrency = cfg.Concurrency
+	}
+
+	if concurrency < 0 {
+		return fmt.Errorf("invalid concurrency %d: it must be 0, for no limit, or positive", concurrency)
+	}
+
+	return nil
+}
+
+// source returns where the value of a flag comes from.
+func source(flags *pflag.FlagSet, name string, configured bool) string {
+	switch {
+	case envFlags[name]:
+		return "env " + config.EnvVar(name)
+	case flags.Changed(name):
+		return "flag --" + name
+	case configured:
+		return "config file"
+	default:
+		return "default"
+	}
+}
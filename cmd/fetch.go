@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/compute"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/store"
+)
+
+// knownTabs are the tabs fetched when no tab is requested.
+var knownTabs = cauldron.KnownTabs
+
+// tabsFor returns the tabs to fetch for the value of a --tab flag: the known
+// tabs by default, and the computed pseudo-tab if there are computed metrics.
+func tabsFor(tab string) []string {
+	if tab != "" {
+		return []string{tab}
+	}
+
+	if len(cfg.Computed) > 0 {
+		return append(append([]string{}, knownTabs...), cauldron.ComputedTab)
+	}
+
+	return knownTabs
+}
+
+// fetchTabs fetches the tabs of a project for a period, returning the results
+// in the same order as the tabs. The computed and derived pseudo-tabs are
+// evaluated over the fetched tabs, also fetching the known tabs they need.
+func fetchTabs(p project.Project, pd period.Period, tabs []string, repoURLs []string) ([]*cauldron.Result, error) {
+	remote := []string{}
+	fetching := map[string]bool{}
+	pseudo := []compute.Metric{}
+	computed := false
+
+	for _, tab := range tabs {
+		switch tab {
+		case cauldron.ComputedTab:
+			if len(cfg.Computed) == 0 {
+				return nil, fmt.Errorf("there are no computed metrics: configure them in the computed section of the configuration file")
+			}
+
+			pseudo = append(pseudo, cfg.Computed...)
+			computed = true
+		case cauldron.DerivedTab:
+			pseudo = append(pseudo, cauldron.DerivedMetrics...)
+		default:
+			remote = append(remote, tab)
+			fetching[tab] = true
+		}
+	}
+
+	for _, tab := range dependencies(pseudo) {
+		if !fetching[tab] {
+			remote = append(remote, tab)
+			fetching[tab] = true
+		}
+	}
+
+	fetched, err := fetchRemoteTabs(p, pd, remote, repoURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	printables := make([]cauldron.Printable, len(fetched))
+	byTab := map[string]*cauldron.Result{}
+	for i, result := range fetched {
+		printables[i] = result.Printable
+		byTab[result.Tab] = result
+	}
+
+	if len(pseudo) > 0 {
+		// the computed metrics can use the derived indicators
+		derived, err := cauldron.NewComputed(cauldron.DerivedMetrics, printables)
+		if err != nil {
+			return nil, err
+		}
+		byTab[cauldron.DerivedTab] = &cauldron.Result{Tab: cauldron.DerivedTab, Printable: derived}
+
+		if computed {
+			c, err := cauldron.NewComputed(cfg.Computed, append(printables, derived))
+			if err != nil {
+				return nil, err
+			}
+			byTab[cauldron.ComputedTab] = &cauldron.Result{Tab: cauldron.ComputedTab, Printable: c}
+		}
+	}
+
+	results := make([]*cauldron.Result, len(tabs))
+	for i, tab := range tabs {
+		results[i] = byTab[tab]
+	}
+
+	return results, nil
+}
+
+// dependencies returns the known tabs reporting the metrics referenced by the
+// expressions of the computed metrics, including the ones of the derived
+// indicators they use.
+func dependencies(metrics []compute.Metric) []string {
+	referenced := map[string]bool{}
+	for _, m := range metrics {
+		e, err := compute.Parse(m.Expr)
+		if err != nil {
+			continue
+		}
+
+		for _, key := range e.Keys() {
+			referenced[cauldron.TabOf(key)] = true
+
+			if cauldron.IsDerived(key) {
+				for _, tab := range dependencies(cauldron.DerivedMetrics) {
+					referenced[tab] = true
+				}
+			}
+		}
+	}
+
+	tabs := []string{}
+	for _, tab := range knownTabs {
+		if referenced[tab] {
+			tabs = append(tabs, tab)
+		}
+	}
+
+	return tabs
+}
+
+// fetchRemoteTabs fetches the tabs of a project for a period concurrently,
+// returning the results in the same order as the tabs. Schema drift is
+// reported in verbose mode, and is an error in strict mode.
+func fetchRemoteTabs(p project.Project, pd period.Period, tabs []string, repoURLs []string) ([]*cauldron.Result, error) {
+	results := make([]*cauldron.Result, len(tabs))
+
+	urls := make([]url.URL, len(tabs))
+	for i, tab := range tabs {
+		u, err := cauldron.Rebase(cauldron.NewURL(p.ID, pd.From, pd.To, tab, repoURLs), baseURL)
+		if err != nil {
+			return nil, err
+		}
+
+		urls[i] = u
+	}
+
+	errorGroup := errgroup.Group{}
+	if concurrency > 0 {
+		errorGroup.SetLimit(concurrency)
+	}
+
+	for i, u := range urls {
+		i, u := i, u
+		errorGroup.Go(func() error {
+			result, err := cauldron.Fetch(u, cauldron.WithToken(token))
+			if err != nil {
+				return err
+			}
+
+			results[i] = result
+			return nil
+		})
+	}
+
+	if err := errorGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	if storePath != "" {
+		if err := saveSnapshots(p, pd, repoURLs, results); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, result := range results {
+		if !result.Drifted() {
+			continue
+		}
+
+		if strict {
+			return nil, fmt.Errorf("project %s (%d): %w", p.Name, p.ID, result.DriftError())
+		}
+
+		if verbose {
+			fmt.Fprintf(os.Stderr, "warning: project %s (%d): %v\n", p.Name, p.ID, result.DriftError())
+		}
+	}
+
+	return results, nil
+}
+
+// fetchProjectsTabs fetches the tabs of several projects, returning the
+// printables indexed by tab and then by project.
+func fetchProjectsTabs(projects []project.Project, pd period.Period, tabs []string, repoURLs []string) ([][]cauldron.Printable, error) {
+	printables := make([][]cauldron.Printable, len(tabs))
+	for i := range printables {
+		printables[i] = make([]cauldron.Printable, len(projects))
+	}
+
+	for p, proj := range projects {
+		results, err := fetchTabs(proj, pd, tabs, repoURLs)
+		if err != nil {
+			return nil, err
+		}
+
+		for t, result := range results {
+			printables[t][p] = result.Printable
+		}
+	}
+
+	return printables, nil
+}
+
+// saveSnapshots records the raw responses of the tabs of a project in the store.
+func saveSnapshots(p project.Project, pd period.Period, repoURLs []string, results []*cauldron.Result) error {
+	s, err := store.Open(storePath)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	fetchedAt := time.Now()
+
+	snapshots := make([]*store.Snapshot, len(results))
+	for i, result := range results {
+		snapshots[i] = &store.Snapshot{
+			ProjectID:   p.ID,
+			ProjectName: p.Name,
+			Tab:         result.Tab,
+			From:        pd.From.Format(period.Layout),
+			To:          pd.To.Format(period.Layout),
+			RepoURLs:    repoURLs,
+			FetchedAt:   fetchedAt,
+			Response:    result.Raw,
+		}
+	}
+
+	return s.Save(snapshots)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+func init() {
+	addSelectionFlags(cmdGoals)
+	cmdGoals.Flags().StringSliceVarP(&repoURLs, "repo-url", "r", []string{}, "The repository URLs to fetch metrics. Default is empty.")
+	cmdGoals.Flags().StringVarP(&format, "format", "F", "console", "The format to output the goals. Possible values are: console, json, markdown, csv and html. Default is console.")
+
+	rootCmd.AddCommand(cmdGoals)
+}
+
+var cmdGoals = &cobra.Command{
+	Use:   "goals",
+	Short: "Track the goals of the projects of the configuration file",
+	Long: `Fetch the metrics of the goals of the projects for their periods, up to
+				  today, printing the current value, the target, the progress towards
+				  it and the value projected to the deadline by the elapsed time.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		projects, err := selectProjects()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if err := goalsRun(projects, repoURLs, time.Now()); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// goalsRun fetches the tab reporting the metric of each goal of the projects
+// for the elapsed part of its period, printing the progress of the goals.
+func goalsRun(projects []project.Project, repoURLs []string, now time.Time) error {
+	goals := []cauldron.GoalProgress{}
+
+	for _, p := range projects {
+		for _, g := range p.Goals {
+			if err := g.Validate(now); err != nil {
+				return fmt.Errorf("project %s (%d): %w", p.Name, p.ID, err)
+			}
+
+			window, err := g.Window(now)
+			if err != nil {
+				return err
+			}
+
+			if now.Before(window.From) {
+				goals = append(goals, cauldron.TrackGoal(p, g, window, now, nil))
+				continue
+			}
+
+			tabs, err := tabsOf([]string{g.Metric})
+			if err != nil {
+				return fmt.Errorf("project %s (%d): goal %q: %w", p.Name, p.ID, g.Label(), err)
+			}
+
+			// the future part of the period has no metrics yet
+			fetched := window
+			if today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()); fetched.To.After(today) {
+				fetched.To = today
+			}
+
+			results, err := fetchTabs(p, fetched, tabs, repoURLs)
+			if err != nil {
+				return err
+			}
+
+			printables := make([]cauldron.Printable, len(results))
+			for i, result := range results {
+				printables[i] = result.Printable
+			}
+
+			goals = append(goals, cauldron.TrackGoal(p, g, window, now, printables))
+		}
+	}
+
+	if len(goals) == 0 {
+		return fmt.Errorf("there are no goals to track: configure them in the goals of the projects of the configuration file")
+	}
+
+	return cauldron.NewGoalReport(goals).Write(os.Stdout, format)
+}
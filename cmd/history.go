@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/store"
+)
+
+var metricKeys []string
+var since string
+var until string
+
+func init() {
+	addSelectionFlags(cmdHistory)
+	cmdHistory.Flags().StringVarP(&tab, "tab", "T", "", "The tab of the snapshots. Default is all the tabs.")
+	cmdHistory.Flags().StringSliceVarP(&metricKeys, "metric", "m", []string{}, "The JSON keys of the metrics to print, listing only the snapshots reporting them. Default is the snapshots, without their metrics.")
+	addRangeFlags(cmdHistory)
+	cmdHistory.Flags().StringVarP(&format, "format", "F", "console", "The format to output the snapshots. Possible values are: console, json, markdown, csv and html. Default is console.")
+
+	rootCmd.AddCommand(cmdHistory)
+}
+
+var cmdHistory = &cobra.Command{
+	Use:   "history",
+	Short: "List the snapshots recorded in the store",
+	Long: `List the snapshots of the tabs recorded in the store with --store, filtered
+				  by project, tab, metric and fetch date, optionally printing the values
+				  of the given metrics.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := historyRun(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func historyRun(cmd *cobra.Command) error {
+	snapshots, err := storedSnapshots(cmd, nil)
+	if err != nil {
+		return err
+	}
+
+	report, err := cauldron.NewHistoryReport(snapshots, metricKeys)
+	if err != nil {
+		return err
+	}
+
+	return report.Write(os.Stdout, format)
+}
+
+// addRangeFlags adds the flags limiting the fetch time of the snapshots to a
+// command.
+func addRangeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&since, "since", "", "Use the snapshots fetched since this date, as YYYY-MM-DD or a relative expression: 30d, last-month... Default is the first snapshot.")
+	cmd.Flags().StringVar(&until, "until", "", "Use the snapshots fetched until this date, included, as YYYY-MM-DD or a relative expression. Default is today, or the end of the --since range.")
+}
+
+// storedSnapshots returns the snapshots of the store matching the selection,
+// --tab and range flags, or the given tabs without --tab, every tab if nil.
+// Without selection flags, the snapshots of every project are returned,
+// including the ones no longer configured.
+func storedSnapshots(cmd *cobra.Command, tabs []string) ([]store.Snapshot, error) {
+	q := store.Query{Tabs: tabs}
+
+	if cmd.Flags().Changed("project-id") || len(selects) > 0 || len(labels) > 0 {
+		projects, err := selectProjects()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range projects {
+			q.ProjectIDs = append(q.ProjectIDs, p.ID)
+		}
+	}
+
+	if tab != "" {
+		q.Tabs = []string{tab}
+	}
+
+	var err error
+	if q.Since, q.Until, err = fetchRange(since, until, time.Now()); err != nil {
+		return nil, err
+	}
+
+	path := storePath
+	if path == "" {
+		path = defaultStorePath()
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("error opening the store, record the snapshots fetching with --store first: %w", err)
+	}
+
+	s, err := store.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	return s.Snapshots(q)
+}
+
+// fetchRange returns the times limiting the fetch time of the snapshots, both
+// included, from the date expressions of the --since and --until flags.
+func fetchRange(since string, until string, now time.Time) (time.Time, time.Time, error) {
+	if since == "" && until == "" {
+		return time.Time{}, time.Time{}, nil
+	}
+
+	if since == "" {
+		// only the end is limited, by the last day of the expression
+		pd, err := period.Parse(until, until, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+
+		return time.Time{}, endOfDay(pd.To), nil
+	}
+
+	pd, err := period.Parse(since, until, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return pd.From, endOfDay(pd.To), nil
+}
+
+func endOfDay(t time.Time) time.Time {
+	return t.AddDate(0, 0, 1).Add(-time.Second)
+}
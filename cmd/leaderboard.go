@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+var metricKey string
+var top int
+var order string
+
+func init() {
+	addSelectionFlags(cmdLeaderboard)
+	cmdLeaderboard.Flags().StringVarP(&metricKey, "metric", "m", "", "The JSON key of the metric ranking the projects, including the derived and computed ones. Required.")
+	cmdLeaderboard.Flags().IntVarP(&top, "top", "n", 10, "The number of projects to print, 0 for all of them. Default is 10.")
+	cmdLeaderboard.Flags().StringVar(&order, "order", "auto", "The order of the ranking. Possible values are: auto, ascending for the metrics better when lower, like the times to close, and descending otherwise, asc and desc. Default is auto.")
+	cmdLeaderboard.Flags().StringVarP(&from, "from", "f", period.DefaultFrom, "The start date to fetch metrics, as YYYY-MM-DD or a relative expression. The ranks are compared with the ones of the previous period of the same length. Default is one year ago.")
+	cmdLeaderboard.Flags().StringVarP(&to, "to", "t", "", "The end date to fetch metrics, as YYYY-MM-DD or a relative expression. Default is today, or the end of the --from range.")
+	cmdLeaderboard.Flags().StringSliceVarP(&repoURLs, "repo-url", "r", []string{}, "The repository URLs to fetch metrics. Default is empty.")
+	cmdLeaderboard.Flags().StringVarP(&format, "format", "F", "console", "The format to output the leaderboard. Possible values are: console, json, markdown, csv and html. Default is console.")
+
+	_ = cmdLeaderboard.MarkFlagRequired("metric")
+
+	rootCmd.AddCommand(cmdLeaderboard)
+}
+
+var cmdLeaderboard = &cobra.Command{
+	Use:   "leaderboard",
+	Short: "Rank the projects by a metric",
+	Long: `Fetch the tab reporting a metric for the projects and rank them by it,
+				  printing the change of rank of each project from the previous
+				  period of the same length.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		projects, err := selectProjects()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if err := leaderboardRun(projects, metricKey, top, order, repoURLs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// leaderboardRun fetches the tab reporting the metric for the projects in the
+// period of the flags and in the previous one, printing the leaderboard.
+func leaderboardRun(projects []project.Project, key string, top int, order string, repoURLs []string) error {
+	var ascending bool
+	switch order {
+	case "auto":
+		ascending = cauldron.LowerIsBetter(key)
+	case "asc":
+		ascending = true
+	case "desc":
+		ascending = false
+	default:
+		return fmt.Errorf("unknown order %q: possible values are auto, asc and desc", order)
+	}
+
+	if top < 0 {
+		return fmt.Errorf("invalid top %d: it must not be negative", top)
+	}
+
+	tabs, err := tabsOf([]string{key})
+	if err != nil {
+		return err
+	}
+
+	pd, err := period.Parse(from, to, time.Now())
+	if err != nil {
+		return err
+	}
+
+	current, err := fetchProjectsTabs(projects, pd, tabs, repoURLs)
+	if err != nil {
+		return err
+	}
+
+	previous, err := fetchProjectsTabs(projects, pd.Previous(), tabs, repoURLs)
+	if err != nil {
+		return err
+	}
+
+	// the metric is reported by a single tab
+	board := cauldron.NewLeaderboard(projects, key, current[0], previous[0], pd, ascending, top)
+
+	return cauldron.NewLeaderboardReport(board).Write(os.Stdout, format)
+}
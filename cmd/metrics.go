@@ -1,43 +1,45 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/sync/errgroup"
+	"github.com/spf13/pflag"
 
 	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
 	"github.com/mdelapenya/cauldrongo/project"
 )
 
-var projectID int
 var from string
 var to string
 var tab string
 var format string
 var repoURLs []string
+var strict bool
+var interval string
+var layout string
+var rank bool
+var group string
+var validate bool
 
 func init() {
-	now := time.Now()
-	yearAgo := now.AddDate(-1, 0, 0)
-
-	// format now in the format YYYY-MM-DD
-	formattedNow := now.Format("2006-01-02")
-	formattedYearAgo := yearAgo.Format("2006-01-02")
-
-	cmdMetrics.Flags().IntVarP(&projectID, "project-id", "p", 0, "The project ID to fetch metrics. Required.")
-	cmdMetrics.Flags().StringVarP(&from, "from", "f", formattedYearAgo, "The start date to fetch metrics. Default is one year ago.")
-	cmdMetrics.Flags().StringVarP(&to, "to", "t", formattedNow, "The end date to fetch metrics. Default is today.")
-	cmdMetrics.Flags().StringVarP(&tab, "tab", "T", "", "The tab to fetch metrics. Possible values are: overview, activity-overview, community-overview, performance-overview. Default is overview.")
-	cmdMetrics.Flags().StringVarP(&format, "format", "F", "console", "The format to output the metrics. Possible values are: console and json. Default is console.")
+	addSelectionFlags(cmdMetrics)
+	cmdMetrics.Flags().StringVarP(&from, "from", "f", period.DefaultFrom, "The start date to fetch metrics, as YYYY-MM-DD or a relative expression: 30d, 12w, 6m, ytd, last-quarter, last-month, this-year... Default is one year ago.")
+	cmdMetrics.Flags().StringVarP(&to, "to", "t", "", "The end date to fetch metrics, as YYYY-MM-DD or a relative expression. Default is today, or the end of the --from range.")
+	cmdMetrics.Flags().StringVarP(&tab, "tab", "T", "", "The tab to fetch metrics. Known values are: overview, activity-overview, community-overview, performance-overview, although any Cauldron tab is supported, and the derived and computed pseudo-tabs. Default is all the known tabs, and the computed one if configured.")
+	cmdMetrics.Flags().StringVarP(&format, "format", "F", "console", "The format to output the metrics. Possible values are: console, json, markdown, csv and html. Default is console.")
 	cmdMetrics.Flags().StringSliceVarP(&repoURLs, "repo-url", "r", []string{}, "The repository URLs to fetch metrics. Default is empty.")
+	cmdMetrics.Flags().BoolVar(&strict, "strict", false, "Fail if a Cauldron response has unknown fields or misses expected ones. Default is false.")
+	cmdMetrics.Flags().StringVarP(&layout, "layout", "l", "list", "The layout of the metrics of several projects. Possible values are: list, printing the tabs of each project one after the other, and matrix, printing one table per tab with a column per project. Default is list.")
+	cmdMetrics.Flags().BoolVar(&rank, "rank", false, "Mark the rank of each project per metric in the matrix layout. Default is false.")
+	cmdMetrics.Flags().StringVarP(&group, "group", "g", "", "The name of a group of projects in the configuration file, to fetch the metrics of its members and roll them up. Default is empty.")
+	cmdMetrics.Flags().BoolVar(&validate, "validate-config", false, "Validate the configuration file before fetching the metrics, failing if it has any issue. Default is false.")
+	cmdMetrics.Flags().StringVarP(&interval, "interval", "i", "", "Split the period into intervals, printing a time series per metric. Possible values are: week, month, quarter and year. Default is no split.")
 
 	rootCmd.AddCommand(cmdMetrics)
 }
@@ -48,122 +50,106 @@ var cmdMetrics = &cobra.Command{
 	Long: `Fetch metrics for a given project. It will return the metrics for the
 				  project in the requested format.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		projects := cfg.Projects
-		runProjects := []project.Project{{ID: projectID, RepoURL: repoURLs}}
-		if len(projects) > 0 {
-			// if the configuration file contains projects, we will ignore the projectID flag
-			runProjects = projects
-		}
-
-		if err := metricsRun(runProjects, from, to, tab, repoURLs); err != nil {
+		runProjects, err := selectProjects()
+		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
-	},
-}
 
-func metricsRun(projects []project.Project, from string, to string, tab string, repoURLs []string) error {
-	writers := make([]io.Writer, len(projects))
-
-	for index, p := range projects {
-		// define a buffer to write the project metrics
-		projectWriter := &strings.Builder{}
-
-		writers[index] = projectWriter
+		if group != "" {
+			if err := groupRun(cmd.Flags(), group, repoURLs, rank); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
 
-		var urls []url.URL
-		if tab == "" {
-			urls = make([]url.URL, 0, 4)
-			urls = append(urls, cauldron.NewURL(p.ID, from, to, "activity-overview", repoURLs))
-			urls = append(urls, cauldron.NewURL(p.ID, from, to, "community-overview", repoURLs))
-			urls = append(urls, cauldron.NewURL(p.ID, from, to, "overview", repoURLs))
-			urls = append(urls, cauldron.NewURL(p.ID, from, to, "performance-overview", repoURLs))
-		} else {
-			urls = make([]url.URL, 0, 1)
-			urls = append(urls, cauldron.NewURL(p.ID, from, to, tab, repoURLs))
+			return
 		}
 
-		// execute all requests concurrently, waiting for the last one to finish, capturing errors
-		// and printing them
-
-		type responsePair struct {
-			reader    io.ReadCloser
-			printable cauldron.Printable
-		}
+		if layout == "matrix" {
+			if interval != "" {
+				fmt.Println("the matrix layout doesn't support intervals")
+				os.Exit(1)
+			}
 
-		responses := make(chan responsePair, len(urls))
+			if err := matrixRun(cmd.Flags(), runProjects, repoURLs, rank); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
 
-		errorGroup := errgroup.Group{}
-		for _, u := range urls {
-			u := u
-			var printable cauldron.Printable
+			return
+		}
 
-			switch u.Query().Get("tab") {
-			case "activity-overview":
-				printable = &cauldron.Activity{}
-			case "community-overview":
-				printable = &cauldron.Community{}
-			case "performance-overview":
-				printable = &cauldron.Performance{}
-			default:
-				printable = &cauldron.Overview{}
+		if interval != "" {
+			i, err := period.ParseInterval(interval)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
 			}
 
-			errorGroup.Go(func() error {
-				reader, code, err := cauldron.HttpRequest(u)
-				if err != nil {
-					return fmt.Errorf("error fetching metrics: %w. URL: %s", err, u.String())
-				}
+			if err := seriesRun(cmd.Flags(), runProjects, i, repoURLs); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
 
-				if code != http.StatusOK {
-					return fmt.Errorf("error fetching metrics: HTTP status code %d. URL: %s", code, u.String())
-				}
+			return
+		}
 
-				responses <- responsePair{reader, printable}
-				return nil
-			})
+		if err := metricsRun(cmd.Flags(), runProjects, repoURLs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
+	},
+}
 
-		if err := errorGroup.Wait(); err != nil {
+// metricsRun prints the tabs of each project one after the other, applying
+// the settings of the projects in the configuration file.
+func metricsRun(flags *pflag.FlagSet, projects []project.Project, repoURLs []string) error {
+	now := time.Now()
+
+	// the CSV header is written only once to stdout, before the first record
+	csvHeader := true
+
+	for _, p := range projects {
+		s, err := projectSettings(flags, p, now)
+		if err != nil {
 			return err
 		}
 
-		// process all responses
-		for i := 0; i < len(urls); i++ {
-			resp := <-responses
-
-			reader := resp.reader
-			printable := resp.printable
+		// define a buffer to write the project metrics
+		projectWriter := &strings.Builder{}
 
-			defer reader.Close()
+		results, err := fetchTabs(p, s.period, s.tabs, repoURLs)
+		if err != nil {
+			return err
+		}
 
-			bs, err := io.ReadAll(reader)
-			if err != nil {
-				return fmt.Errorf("error reading metrics: %w", err)
-			}
+		// the projects written to their own file always include the CSV header
+		header := csvHeader || p.Output != ""
 
-			if err := json.Unmarshal(bs, printable); err != nil {
-				return fmt.Errorf("error unmarshalling metrics: %w", err)
+		// process all responses
+		for _, result := range results {
+			printable := result.Printable
+			if len(p.Fields) > 0 {
+				printable = cauldron.Select(printable, p.Fields)
 			}
 
-			var urlTab string
-			switch printable.(type) {
-			case *cauldron.Activity:
-				urlTab = "activity-overview"
-			case *cauldron.Community:
-				urlTab = "community-overview"
-			case *cauldron.Performance:
-				urlTab = "performance-overview"
-			default:
-				urlTab = "overview"
+			if len(p.Thresholds) > 0 {
+				printable = cauldron.WithThresholds(printable, p.Thresholds)
 			}
 
 			var formatter cauldron.Formatter
-			switch format {
+			switch s.format {
 			case "json":
-				formatter = cauldron.NewJSONFormatter(p, from, to, urlTab, "  ", projectWriter)
+				formatter = cauldron.NewJSONFormatter(p, s.period.From, s.period.To, result.Tab, "  ", projectWriter)
+			case "markdown":
+				formatter = cauldron.NewMarkdownFormatter(p, s.period.From, s.period.To, result.Tab, projectWriter)
+			case "csv":
+				formatter = cauldron.NewCSVFormatter(p, s.period.From, s.period.To, result.Tab, header, projectWriter)
+				header = false
+			case "html":
+				formatter = cauldron.NewHTMLFormatter(p, s.period.From, s.period.To, result.Tab, projectWriter)
 			default:
-				formatter = cauldron.NewConsoleFormatter(p, from, to, urlTab, projectWriter)
+				formatter = cauldron.NewConsoleFormatter(p, s.period.From, s.period.To, result.Tab, projectWriter)
 			}
 
 			if err := formatter.Format(printable); err != nil {
@@ -171,8 +157,160 @@ func metricsRun(projects []project.Project, from string, to string, tab string,
 			}
 		}
 
+		if p.Output != "" {
+			if err := os.WriteFile(p.Output, []byte(projectWriter.String()), 0o644); err != nil {
+				return fmt.Errorf("error writing the metrics of project %s (%d): %w", p.Name, p.ID, err)
+			}
+
+			continue
+		}
+
+		if s.format == "csv" {
+			csvHeader = false
+		}
+
 		fmt.Fprintln(os.Stdout, projectWriter.String())
 	}
 
 	return nil
 }
+
+// seriesRun splits the period of each project into windows of the given
+// interval, fetching its tabs for each one of them, and prints a time series
+// per metric, applying the settings of the projects in the configuration file.
+func seriesRun(flags *pflag.FlagSet, projects []project.Project, interval period.Interval, repoURLs []string) error {
+	now := time.Now()
+
+	reports := []cauldron.Report{}
+	for _, p := range projects {
+		s, err := projectSettings(flags, p, now)
+		if err != nil {
+			return err
+		}
+
+		windows := s.period.Split(interval)
+
+		series, err := fetchSeries(p, windows, s.tabs, repoURLs)
+		if err != nil {
+			return err
+		}
+
+		for t, tab := range s.tabs {
+			reports = append(reports, cauldron.NewSeriesReport(p, s.period, interval, tab, windows, selectSeries(series[t], p.Fields)))
+		}
+	}
+
+	return cauldron.MergeReports(reports...).Write(os.Stdout, format)
+}
+
+// selectSeries returns the series of the metrics with the given JSON keys,
+// or all of them if there are no keys.
+func selectSeries(series []cauldron.Series, keys []string) []cauldron.Series {
+	if len(keys) == 0 {
+		return series
+	}
+
+	selected := []cauldron.Series{}
+	for _, s := range series {
+		if slices.Contains(keys, s.Key) {
+			selected = append(selected, s)
+		}
+	}
+
+	return selected
+}
+
+// fetchSeries fetches the tabs of a project for each window, returning the
+// time series of each tab in the same order as the tabs.
+func fetchSeries(p project.Project, windows []period.Period, tabs []string, repoURLs []string) ([][]cauldron.Series, error) {
+	// printables[tab][window]
+	printables := make([][]cauldron.Printable, len(tabs))
+	for i := range printables {
+		printables[i] = make([]cauldron.Printable, len(windows))
+	}
+
+	for w, window := range windows {
+		results, err := fetchTabs(p, window, tabs, repoURLs)
+		if err != nil {
+			return nil, err
+		}
+
+		for t, result := range results {
+			printables[t][w] = result.Printable
+		}
+	}
+
+	series := make([][]cauldron.Series, len(tabs))
+	for t := range tabs {
+		series[t] = cauldron.NewSeries(windows, printables[t])
+	}
+
+	return series, nil
+}
+
+// groupRun fetches the tabs of the members of a group, printing one table per
+// tab with a column per member and a column with the rolled up values.
+func groupRun(flags *pflag.FlagSet, name string, repoURLs []string, rank bool) error {
+	g, err := project.FindGroup(cfg.Groups, name)
+	if err != nil {
+		return err
+	}
+
+	members, err := g.Members(cfg.Projects)
+	if err != nil {
+		return err
+	}
+
+	s, err := sharedSettings(flags, members, "group", time.Now())
+	if err != nil {
+		return err
+	}
+
+	printables, err := fetchProjectsTabs(members, s.period, s.tabs, repoURLs)
+	if err != nil {
+		return err
+	}
+
+	reports := make([]cauldron.Report, len(s.tabs))
+	for t, tab := range s.tabs {
+		rollup := cauldron.NewRollup(cauldron.NewMatrix(selectFields(members, printables[t]), rank))
+		reports[t] = cauldron.NewRollupReport(g.Name, members, s.period, tab, rollup)
+	}
+
+	return cauldron.MergeReports(reports...).Write(os.Stdout, s.format)
+}
+
+// matrixRun fetches the tabs of all the projects, printing one table per tab
+// with the metrics as rows and the projects as columns.
+func matrixRun(flags *pflag.FlagSet, projects []project.Project, repoURLs []string, rank bool) error {
+	s, err := sharedSettings(flags, projects, "matrix", time.Now())
+	if err != nil {
+		return err
+	}
+
+	printables, err := fetchProjectsTabs(projects, s.period, s.tabs, repoURLs)
+	if err != nil {
+		return err
+	}
+
+	reports := make([]cauldron.Report, len(s.tabs))
+	for t, tab := range s.tabs {
+		reports[t] = cauldron.NewMatrixReport(projects, s.period, tab, cauldron.NewMatrix(selectFields(projects, printables[t]), rank))
+	}
+
+	return cauldron.MergeReports(reports...).Write(os.Stdout, s.format)
+}
+
+// selectFields applies the fields of the projects to their printables of a
+// tab, at the same index.
+func selectFields(projects []project.Project, printables []cauldron.Printable) []cauldron.Printable {
+	selected := make([]cauldron.Printable, len(printables))
+	for i, p := range printables {
+		selected[i] = p
+		if len(projects[i].Fields) > 0 {
+			selected[i] = cauldron.Select(p, projects[i].Fields)
+		}
+	}
+
+	return selected
+}
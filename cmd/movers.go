@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+var minBase float64
+
+func init() {
+	addSelectionFlags(cmdMovers)
+	cmdMovers.Flags().StringVar(&fromA, "from-a", "", "The start date of the first period, as YYYY-MM-DD or a relative expression. Default is the period of the same length right before the second one.")
+	cmdMovers.Flags().StringVar(&toA, "to-a", "", "The end date of the first period. Default is today, or the end of the --from-a range.")
+	cmdMovers.Flags().StringVar(&fromB, "from-b", "", "The start date of the second period, as YYYY-MM-DD or a relative expression. Default is 30 days ago.")
+	cmdMovers.Flags().StringVar(&toB, "to-b", "", "The end date of the second period. Default is today, or the end of the --from-b range.")
+	cmdMovers.Flags().StringVarP(&tab, "tab", "T", "", "The tab to compare. Default is all the known tabs, and the computed one if configured.")
+	cmdMovers.Flags().IntVarP(&top, "top", "n", 10, "The number of movers to print per kind of change, 0 for all of them. Default is 10.")
+	cmdMovers.Flags().Float64Var(&minBase, "min-base", 10, "The minimum absolute value in the first period of the relative changes, skipping the noisy changes from very small values. Default is 10.")
+	cmdMovers.Flags().StringSliceVarP(&repoURLs, "repo-url", "r", []string{}, "The repository URLs to fetch metrics. Default is empty.")
+	cmdMovers.Flags().StringVarP(&format, "format", "F", "console", "The format to output the movers. Possible values are: console, json, markdown, csv and html. Default is console.")
+
+	rootCmd.AddCommand(cmdMovers)
+}
+
+var cmdMovers = &cobra.Command{
+	Use:   "movers",
+	Short: "List the metrics that changed most between two periods",
+	Long: `Compare the metrics of all the tabs of the projects between two periods, A
+				  and B, listing the largest relative and absolute increases and
+				  decreases across all of them, as a digest of what changed most.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		a, b, err := moversPeriods(fromA, toA, fromB, toB, time.Now())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		projects, err := selectProjects()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if err := moversRun(projects, a, b, tab, repoURLs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// moversPeriods returns the compared periods: B is the last 30 days by
+// default, and A the period of the same length right before B.
+func moversPeriods(fromA string, toA string, fromB string, toB string, now time.Time) (period.Period, period.Period, error) {
+	if fromB == "" {
+		fromB = "30d"
+	}
+
+	b, err := period.Parse(fromB, toB, now)
+	if err != nil {
+		return period.Period{}, period.Period{}, fmt.Errorf("period B: %w", err)
+	}
+
+	if fromA == "" && toA == "" {
+		return b.Previous(), b, nil
+	}
+
+	a, err := period.Parse(fromA, toA, now)
+	if err != nil {
+		return period.Period{}, period.Period{}, fmt.Errorf("period A: %w", err)
+	}
+
+	return a, b, nil
+}
+
+// moversRun fetches the tabs of the projects for both periods, printing the
+// largest changes of their metrics.
+func moversRun(projects []project.Project, a period.Period, b period.Period, tab string, repoURLs []string) error {
+	if top < 0 {
+		return fmt.Errorf("invalid top %d: it must not be negative", top)
+	}
+
+	tabs := tabsFor(tab)
+
+	compared := []cauldron.Mover{}
+	for _, p := range projects {
+		resultsA, err := fetchTabs(p, a, tabs, repoURLs)
+		if err != nil {
+			return err
+		}
+
+		resultsB, err := fetchTabs(p, b, tabs, repoURLs)
+		if err != nil {
+			return err
+		}
+
+		printablesA := make([]cauldron.Printable, len(tabs))
+		printablesB := make([]cauldron.Printable, len(tabs))
+		for i := range tabs {
+			printablesA[i] = resultsA[i].Printable
+			printablesB[i] = resultsB[i].Printable
+		}
+
+		compared = append(compared, cauldron.CompareProject(p, tabs, printablesA, printablesB)...)
+	}
+
+	movers := cauldron.NewMovers(compared, a, b, top, minBase)
+
+	return cauldron.NewMoversReport(movers).Write(os.Stdout, format)
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+var projectIDs []int
+var selects []string
+var labels []string
+
+// addSelectionFlags adds the flags to select the projects to a command.
+func addSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().IntSliceVarP(&projectIDs, "project-id", "p", []int{}, "The project IDs to fetch metrics. If the configuration file contains projects, it selects them by ID. Required if there is no configuration file.")
+	cmd.Flags().StringArrayVar(&selects, "select", []string{}, "Select the configured projects by name=<pattern> or id=<id>. It can be repeated.")
+	cmd.Flags().StringArrayVar(&labels, "label", []string{}, "Select the configured projects with the key=value label. If repeated, all the labels must match.")
+}
+
+// selectProjects returns the projects to fetch metrics for: the configured
+// projects matching the selection flags, or the ones identified by the
+// --project-id flag if there are no configured projects.
+func selectProjects() ([]project.Project, error) {
+	selector, err := project.ParseSelector(projectIDs, selects, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Projects) > 0 {
+		return selector.Filter(cfg.Projects)
+	}
+
+	if len(selects) > 0 || len(labels) > 0 {
+		return nil, fmt.Errorf("there are no configured projects to select from")
+	}
+
+	if len(projectIDs) == 0 {
+		return nil, fmt.Errorf("no project to fetch metrics for: pass --project-id or configure the projects")
+	}
+
+	projects := make([]project.Project, len(projectIDs))
+	for i, id := range projectIDs {
+		projects[i] = project.Project{ID: id, RepoURL: repoURLs}
+	}
+
+	return projects, nil
+}
+
+// settings are the effective options to fetch and print the metrics of a project.
+type settings struct {
+	period period.Period
+	tabs   []string
+	format string
+}
+
+// projectSettings resolves the settings of a project, with the following
+// precedence: the flags explicitly set, then the settings of the project in
+// the configuration file, and finally the defaults of the flags.
+func projectSettings(flags *pflag.FlagSet, p project.Project, now time.Time) (settings, error) {
+	s := settings{format: format, tabs: tabsFor(tab)}
+
+	if !flags.Changed("format") && p.Format != "" {
+		s.format = p.Format
+	}
+
+	if !flags.Changed("tab") && len(p.Tabs) > 0 {
+		s.tabs = p.Tabs
+	}
+
+	projectFrom, projectTo := from, to
+	if !flags.Changed("from") && p.From != "" {
+		projectFrom = p.From
+	}
+
+	if !flags.Changed("to") && p.To != "" {
+		projectTo = p.To
+	}
+
+	pd, err := period.Parse(projectFrom, projectTo, now)
+	if err != nil {
+		return settings{}, fmt.Errorf("project %s (%d): %w", p.Name, p.ID, err)
+	}
+	s.period = pd
+
+	return s, nil
+}
+
+// sharedSettings resolves the settings of the projects compared side by side
+// in a layout, failing if their settings in the configuration file result in
+// different periods or tabs.
+func sharedSettings(flags *pflag.FlagSet, projects []project.Project, layout string, now time.Time) (settings, error) {
+	if len(projects) == 0 {
+		return projectSettings(flags, project.Project{}, now)
+	}
+
+	shared, err := projectSettings(flags, projects[0], now)
+	if err != nil {
+		return settings{}, err
+	}
+
+	for _, p := range projects[1:] {
+		s, err := projectSettings(flags, p, now)
+		if err != nil {
+			return settings{}, err
+		}
+
+		if s.period != shared.period || !slices.Equal(s.tabs, shared.tabs) {
+			return settings{}, fmt.Errorf("project %s (%d) has another period or tabs than project %s (%d) in the configuration file, but the %s layout compares the projects over the same ones: set them with --from, --to and --tab", p.Name, p.ID, projects[0].Name, projects[0].ID, layout)
+		}
+	}
+
+	// the projects are printed together, so only the flag sets the format
+	shared.format = format
+
+	return shared, nil
+}
@@ -3,25 +3,58 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
-	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/config"
+	"github.com/mdelapenya/cauldrongo/store"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 const (
 	// DefaultConfigFile is the default configuration file
-	DefaultConfigFile = ".cauldrongo.yml"
+	DefaultConfigFile = config.DefaultFile
 )
 
 var cfgFile string
-var cfg Config
+var mergeConfig bool
+
+// cfgFiles are the configuration files read, by priority.
+var cfgFiles []string
+var verbose bool
+var baseURL string
+var token string
+var concurrency int
+var storePath string
+var cfg config.Config
+
+// cfgErr is the error reading the configuration files, reported before
+// running any command but the ones validating or editing the file.
+var cfgErr error
 
 var rootCmd = &cobra.Command{
 	Use:   "cauldrongo",
 	Short: "Cauldron Go is a client for the Cauldron APIs.",
 	Long: `A Fast and Flexible Go client for the Cauldron APIs built with
 				  love by mdelapenya and friends in Go.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := applyGlobals(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if validate {
+			if err := validateConfig(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+
+		if cfgErr != nil {
+			fmt.Println("Can't unmarshal projects:", cfgErr)
+			os.Exit(1)
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		//
 	},
@@ -36,39 +69,66 @@ func Execute() {
 
 func init() {
 	cobra.OnInitialize(initConfig)
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", DefaultConfigFile, "config file (default is .cauldrongo.yml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is the first found of ./.cauldrongo.yml, $XDG_CONFIG_HOME/cauldrongo/config.yml and ~/.cauldrongo.yml)")
+	rootCmd.PersistentFlags().BoolVar(&mergeConfig, "merge-config", false, "merge all the config files found, instead of using the first one")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "print warnings and diagnostics to stderr")
+	rootCmd.PersistentFlags().StringVar(&baseURL, "base-url", cauldron.DefaultBaseURL, "base URL of the Cauldron instance, e.g. a self-hosted one")
+	rootCmd.PersistentFlags().StringVar(&token, "token", "", "API token of a Cauldron user, to access private projects")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 0, "maximum number of concurrent requests to Cauldron (default is no limit)")
+	rootCmd.PersistentFlags().StringVar(&storePath, "store", "", "record the fetched tabs in the SQLite store at this path, or at $XDG_DATA_HOME/cauldrongo/history.db (~/.local/share by default) if passed without a value")
+	rootCmd.PersistentFlags().Lookup("store").NoOptDefVal = defaultStorePath()
 }
 
-type Config struct {
-	Projects []project.Project `mapstructure:"projects"`
+// defaultStorePath returns the default path of the store of snapshots.
+func defaultStorePath() string {
+	home, _ := os.UserHomeDir()
+	return store.DefaultPath(home, os.Getenv("XDG_DATA_HOME"))
+}
+
+// applyGlobals sets the flags of a command from their environment variables,
+// and resolves the global settings.
+func applyGlobals(cmd *cobra.Command) error {
+	if err := applyEnv(cmd.Flags()); err != nil {
+		return err
+	}
+
+	return resolveGlobals(cmd.Flags())
 }
 
 func initConfig() {
-	// Don't forget to read config either from cfgFile or from home directory!
-	if cfgFile != "" {
-		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
-	} else {
-		// Find home directory.
-		home, err := os.UserHomeDir()
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+	// the configuration file can be set with CAULDRONGO_CONFIG
+	if err := applyEnv(rootCmd.PersistentFlags()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// the home directory is optional, as the configuration file is
+	home, _ := os.UserHomeDir()
+
+	paths := config.Discover(cfgFile, config.Candidates(home, os.Getenv("XDG_CONFIG_HOME")), mergeConfig)
+	if len(paths) == 0 {
+		if cfgFile != "" {
+			fmt.Fprintf(os.Stderr, "Can't read config file %s, using flags\n", cfgFile)
+		} else if verbose {
+			fmt.Fprintln(os.Stderr, "No config file found, using flags")
 		}
 
-		// Search config in home directory with name ".cauldrongo.yml"
-		viper.AddConfigPath(home)
-		viper.SetConfigName(".cauldrongo.yml")
+		return
 	}
 
-	if err := viper.ReadInConfig(); err != nil {
-		fmt.Println("Can't read config file, using flags:", err)
-		return
+	// the layers are merged from the lowest priority to the highest one
+	for i := len(paths) - 1; i >= 0; i-- {
+		c, files, err := config.Load(paths[i])
+		if err != nil {
+			cfgErr = err
+			return
+		}
+
+		cfg = config.Merge(cfg, c)
+		cfgFiles = append(files, cfgFiles...)
 	}
 
-	err := viper.Unmarshal(&cfg)
-	if err != nil {
-		fmt.Println("Can't unmarshal projects:", err)
-		os.Exit(1)
+	if verbose {
+		fmt.Fprintln(os.Stderr, "Using config files:", strings.Join(cfgFiles, ", "))
 	}
 }
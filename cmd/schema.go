@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/config"
+	"github.com/mdelapenya/cauldrongo/schema"
+)
+
+func init() {
+	cmdSchema.AddCommand(cmdSchemaConfig)
+	cmdSchema.AddCommand(cmdSchemaOutput)
+	rootCmd.AddCommand(cmdSchema)
+}
+
+var cmdSchema = &cobra.Command{
+	Use:   "schema",
+	Short: "Print JSON Schemas",
+	Long: `Print the JSON Schemas of the configuration file, for editors to autocomplete
+				  and validate it, and of the JSON output, as a contract for its consumers.`,
+	// the schemas don't depend on the configuration file
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+}
+
+var cmdSchemaConfig = &cobra.Command{
+	Use:   "config",
+	Short: "Print the JSON Schema of the configuration file",
+	Long:  `Print the JSON Schema of the configuration file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := printSchema(config.Schema()); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var cmdSchemaOutput = &cobra.Command{
+	Use:   "output",
+	Short: "Print the JSON Schema of the JSON output",
+	Long: `Print the JSON Schema of the documents written with the json format. Their
+				  schema_version field is increased on breaking changes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := printSchema(cauldron.OutputSchema()); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func printSchema(s schema.Schema) error {
+	bs, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling the schema: %w", err)
+	}
+
+	fmt.Println(string(bs))
+	return nil
+}
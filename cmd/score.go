@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/score"
+)
+
+func init() {
+	addSelectionFlags(cmdScore)
+	cmdScore.Flags().StringVarP(&from, "from", "f", period.DefaultFrom, "The start date to fetch metrics, as YYYY-MM-DD or a relative expression. Default is one year ago.")
+	cmdScore.Flags().StringVarP(&to, "to", "t", "", "The end date to fetch metrics, as YYYY-MM-DD or a relative expression. Default is today, or the end of the --from range.")
+	cmdScore.Flags().StringSliceVarP(&metricKeys, "metric", "m", []string{}, "The JSON keys of the metrics of the score, weighing the same and normalized between the projects. Default is the score metrics of the configuration file.")
+	cmdScore.Flags().StringSliceVarP(&repoURLs, "repo-url", "r", []string{}, "The repository URLs to fetch metrics. Default is empty.")
+	cmdScore.Flags().StringVarP(&format, "format", "F", "console", "The format to output the scores. Possible values are: console, json, markdown, csv and html. Default is console.")
+
+	rootCmd.AddCommand(cmdScore)
+}
+
+var cmdScore = &cobra.Command{
+	Use:   "score",
+	Short: "Rank the projects by a weighted health score",
+	Long: `Fetch the metrics of the score for the projects and combine them into a
+				  0-100 health score, normalizing each metric against its target or
+				  between the worst and the best projects, and weighing it as configured
+				  in the score section of the configuration file. The contribution of
+				  every metric is printed, so the score is explainable.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		projects, err := selectProjects()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		metrics := cfg.Score
+		if len(metricKeys) > 0 {
+			metrics = make([]score.Metric, len(metricKeys))
+			for i, key := range metricKeys {
+				metrics[i] = score.Metric{Key: key}
+			}
+		}
+
+		if err := scoreRun(projects, metrics, repoURLs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// scoreRun fetches the tabs reporting the metrics of the score for each
+// project in the same period, printing the scores.
+func scoreRun(projects []project.Project, metrics []score.Metric, repoURLs []string) error {
+	if len(metrics) == 0 {
+		return fmt.Errorf("there are no metrics to score: configure them in the score section of the configuration file, or pass them with --metric")
+	}
+
+	keys := make([]string, len(metrics))
+	for i, m := range metrics {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+
+		keys[i] = m.Key
+	}
+
+	tabs, err := tabsOf(keys)
+	if err != nil {
+		return err
+	}
+
+	pd, err := period.Parse(from, to, time.Now())
+	if err != nil {
+		return err
+	}
+
+	printables := make([][]cauldron.Printable, len(projects))
+	for i, p := range projects {
+		results, err := fetchTabs(p, pd, tabs, repoURLs)
+		if err != nil {
+			return err
+		}
+
+		printables[i] = make([]cauldron.Printable, len(results))
+		for j, result := range results {
+			printables[i][j] = result.Printable
+		}
+	}
+
+	return cauldron.NewScoreReport(cauldron.NewScores(projects, metrics, printables)).Write(os.Stdout, format)
+}
+
+// tabsOf returns the tabs reporting the metrics with the given JSON keys:
+// the known tabs, and the derived and computed pseudo-tabs.
+func tabsOf(keys []string) ([]string, error) {
+	computed := map[string]bool{}
+	for _, m := range cfg.Computed {
+		computed[m.Key] = true
+	}
+
+	wanted := map[string]bool{}
+	for _, key := range keys {
+		switch {
+		case computed[key]:
+			wanted[cauldron.ComputedTab] = true
+		case cauldron.IsDerived(key):
+			wanted[cauldron.DerivedTab] = true
+		case cauldron.TabOf(key) != "":
+			wanted[cauldron.TabOf(key)] = true
+		default:
+			return nil, fmt.Errorf("unknown metric %q: it's not reported by the known tabs, nor a derived or computed metric", key)
+		}
+	}
+
+	tabs := []string{}
+	for _, tab := range append(append([]string{}, knownTabs...), cauldron.DerivedTab, cauldron.ComputedTab) {
+		if wanted[tab] {
+			tabs = append(tabs, tab)
+		}
+	}
+
+	return tabs, nil
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mdelapenya/cauldrongo/cauldron"
+)
+
+func init() {
+	addSelectionFlags(cmdTrend)
+	cmdTrend.Flags().StringVarP(&tab, "tab", "T", "", "The tab of the metrics. Default is all the tabs.")
+	cmdTrend.Flags().StringSliceVarP(&metricKeys, "metric", "m", []string{}, "The JSON keys of the metrics. Default is all the metrics of the snapshots.")
+	addRangeFlags(cmdTrend)
+	cmdTrend.Flags().StringVarP(&format, "format", "F", "console", "The format to output the trends. Possible values are: console, json, markdown, csv and html. Default is console.")
+
+	rootCmd.AddCommand(cmdTrend)
+}
+
+var cmdTrend = &cobra.Command{
+	Use:   "trend",
+	Short: "Print the trends of the metrics recorded in the store",
+	Long: `Print the evolution of the metrics over the snapshots recorded in the store
+				  with --store, from the oldest to the newest: a sparkline, the minimum,
+				  maximum and last values, and the direction of the line fitting them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := trendRun(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func trendRun(cmd *cobra.Command) error {
+	snapshots, err := storedSnapshots(cmd, nil)
+	if err != nil {
+		return err
+	}
+
+	trends, err := cauldron.NewTrends(snapshots, metricKeys)
+	if err != nil {
+		return err
+	}
+
+	return cauldron.NewTrendReport(trends).Write(os.Stdout, format)
+}
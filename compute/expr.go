@@ -0,0 +1,310 @@
+package compute
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// Expr is a parsed arithmetic expression over the JSON keys of the metrics.
+type Expr interface {
+	// Eval returns the value of the expression, or nil if a metric it
+	// references is missing or it divides by zero.
+	Eval(values map[string]*float64) *float64
+	// Keys returns the JSON keys of the metrics referenced by the expression.
+	Keys() []string
+}
+
+// functions are the functions supported by the expressions, by name.
+var functions = map[string]func(args []float64) float64{
+	"abs": func(args []float64) float64 { return math.Abs(args[0]) },
+	"min": func(args []float64) float64 { return math.Min(args[0], args[1]) },
+	"max": func(args []float64) float64 { return math.Max(args[0], args[1]) },
+}
+
+// arities are the number of arguments of the functions.
+var arities = map[string]int{"abs": 1, "min": 2, "max": 2}
+
+type number float64
+
+func (n number) Eval(map[string]*float64) *float64 {
+	v := float64(n)
+	return &v
+}
+
+func (n number) Keys() []string {
+	return []string{}
+}
+
+type variable string
+
+func (v variable) Eval(values map[string]*float64) *float64 {
+	return values[string(v)]
+}
+
+func (v variable) Keys() []string {
+	return []string{string(v)}
+}
+
+type negation struct {
+	operand Expr
+}
+
+func (n negation) Eval(values map[string]*float64) *float64 {
+	v := n.operand.Eval(values)
+	if v == nil {
+		return nil
+	}
+
+	result := -*v
+	return &result
+}
+
+func (n negation) Keys() []string {
+	return n.operand.Keys()
+}
+
+type binary struct {
+	operator    rune
+	left, right Expr
+}
+
+func (b binary) Eval(values map[string]*float64) *float64 {
+	l, r := b.left.Eval(values), b.right.Eval(values)
+	if l == nil || r == nil {
+		return nil
+	}
+
+	var result float64
+	switch b.operator {
+	case '+':
+		result = *l + *r
+	case '-':
+		result = *l - *r
+	case '*':
+		result = *l * *r
+	default:
+		// the ratios of empty periods are not available, instead of infinite
+		if *r == 0 {
+			return nil
+		}
+
+		result = *l / *r
+	}
+
+	return &result
+}
+
+func (b binary) Keys() []string {
+	return append(b.left.Keys(), b.right.Keys()...)
+}
+
+type call struct {
+	name string
+	args []Expr
+}
+
+func (c call) Eval(values map[string]*float64) *float64 {
+	args := make([]float64, len(c.args))
+	for i, arg := range c.args {
+		v := arg.Eval(values)
+		if v == nil {
+			return nil
+		}
+
+		args[i] = *v
+	}
+
+	result := functions[c.name](args)
+	return &result
+}
+
+func (c call) Keys() []string {
+	keys := []string{}
+	for _, arg := range c.args {
+		keys = append(keys, arg.Keys()...)
+	}
+
+	return keys
+}
+
+// Parse parses an arithmetic expression with numbers, the JSON keys of the
+// metrics, the +, -, * and / operators, parentheses and the abs, min and max
+// functions, e.g. issues_closed_activity_overview / issues_created_activity_overview.
+func Parse(s string) (Expr, error) {
+	p := &parser{input: []rune(s)}
+
+	e, err := p.expr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", s, err)
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("invalid expression %q: unexpected %q at position %d", s, p.input[p.pos], p.pos+1)
+	}
+
+	return e, nil
+}
+
+// parser is a recursive descent parser of the expressions, where * and /
+// take precedence over + and -.
+type parser struct {
+	input []rune
+	pos   int
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// peek returns the next rune after the spaces, or 0 at the end.
+func (p *parser) peek() rune {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+func (p *parser) expr() (Expr, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binary{operator: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) term() (Expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binary{operator: op, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) unary() (Expr, error) {
+	if p.peek() == '-' {
+		p.pos++
+
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return negation{operand: operand}, nil
+	}
+
+	return p.primary()
+}
+
+func (p *parser) primary() (Expr, error) {
+	r := p.peek()
+
+	switch {
+	case r == 0:
+		return nil, fmt.Errorf("unexpected end")
+	case r == '(':
+		p.pos++
+
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ) at position %d", p.pos+1)
+		}
+		p.pos++
+
+		return e, nil
+	case unicode.IsDigit(r) || r == '.':
+		start := p.pos
+		for p.pos < len(p.input) && (unicode.IsDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+			p.pos++
+		}
+
+		v, err := strconv.ParseFloat(string(p.input[start:p.pos]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", string(p.input[start:p.pos]), start+1)
+		}
+
+		return number(v), nil
+	case r == '_' || unicode.IsLetter(r):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '_' || unicode.IsLetter(p.input[p.pos]) || unicode.IsDigit(p.input[p.pos])) {
+			p.pos++
+		}
+
+		name := string(p.input[start:p.pos])
+		if p.peek() != '(' {
+			return variable(name), nil
+		}
+
+		return p.call(name, start)
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", r, p.pos+1)
+	}
+}
+
+func (p *parser) call(name string, start int) (Expr, error) {
+	arity, ok := arities[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s at position %d", name, start+1)
+	}
+
+	// the opening parenthesis
+	p.pos++
+
+	args := []Expr{}
+	for p.peek() != ')' {
+		if len(args) > 0 {
+			if p.peek() != ',' {
+				return nil, fmt.Errorf("missing , or ) at position %d", p.pos+1)
+			}
+			p.pos++
+		}
+
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+	p.pos++
+
+	if len(args) != arity {
+		return nil, fmt.Errorf("function %s expects %d arguments but got %d", name, arity, len(args))
+	}
+
+	return call{name: name, args: args}, nil
+}
@@ -0,0 +1,116 @@
+package compute_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/compute"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestEval(t *testing.T) {
+	values := map[string]*float64{
+		"issues_closed_activity_overview":  float(30),
+		"issues_created_activity_overview": float(40),
+		"reviews_activity_overview":        float(0),
+		"commits_overview":                 nil,
+	}
+
+	testCases := []struct {
+		expr     string
+		expected *float64
+	}{
+		{expr: "issues_closed_activity_overview / issues_created_activity_overview", expected: float(0.75)},
+		{expr: "1 + 2 * 3", expected: float(7)},
+		{expr: "(1 + 2) * 3", expected: float(9)},
+		{expr: "10 - 4 - 3", expected: float(3)},
+		{expr: "-issues_closed_activity_overview + 1", expected: float(-29)},
+		{expr: "max(issues_closed_activity_overview, 35) - min(1, 2.5)", expected: float(34)},
+		{expr: "abs(issues_closed_activity_overview - issues_created_activity_overview)", expected: float(10)},
+		{expr: "issues_closed_activity_overview / reviews_activity_overview", expected: nil},
+		{expr: "commits_overview * 2", expected: nil},
+		{expr: "unknown_overview", expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.expr, func(tt *testing.T) {
+			tt.Parallel()
+
+			e, err := compute.Parse(testCase.expr)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			got := e.Eval(values)
+			if (got == nil) != (testCase.expected == nil) || (got != nil && *got != *testCase.expected) {
+				tt.Fatalf("expected %v but got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{expr: "", expected: `invalid expression "": unexpected end`},
+		{expr: "commits_overview +", expected: `invalid expression "commits_overview +": unexpected end`},
+		{expr: "(commits_overview", expected: `invalid expression "(commits_overview": missing ) at position 18`},
+		{expr: "commits_overview commits_overview", expected: `invalid expression "commits_overview commits_overview": unexpected 'c' at position 18`},
+		{expr: "commits_overview % 2", expected: `invalid expression "commits_overview % 2": unexpected '%' at position 18`},
+		{expr: "sqrt(commits_overview)", expected: `invalid expression "sqrt(commits_overview)": unknown function sqrt at position 1`},
+		{expr: "max(commits_overview)", expected: `invalid expression "max(commits_overview)": function max expects 2 arguments but got 1`},
+		{expr: "1.2.3", expected: `invalid expression "1.2.3": invalid number "1.2.3" at position 1`},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.expr, func(tt *testing.T) {
+			tt.Parallel()
+
+			_, err := compute.Parse(testCase.expr)
+			if err == nil || err.Error() != testCase.expected {
+				tt.Fatalf("expected %q but got %v", testCase.expected, err)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	e, err := compute.Parse("max(a_overview, 1) / (b_overview - -c_overview)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := e.Keys()
+	if len(keys) != 3 || keys[0] != "a_overview" || keys[1] != "b_overview" || keys[2] != "c_overview" {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+}
+
+func TestMetricValidate(t *testing.T) {
+	if err := (compute.Metric{Key: "issue_closure_ratio", Expr: "a / b"}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (compute.Metric{Key: "Issue closure", Expr: "a / b"}).Validate(); err == nil {
+		t.Fatal("expected an error for an invalid key")
+	}
+
+	if err := (compute.Metric{Key: "ratio", Expr: "a /"}).Validate(); err == nil {
+		t.Fatal("expected an error for an invalid expression")
+	}
+
+	precision := 0
+	if digits := (compute.Metric{Precision: &precision}).Digits(); digits != 0 {
+		t.Fatalf("expected 0 digits but got %d", digits)
+	}
+
+	if digits := (compute.Metric{}).Digits(); digits != compute.DefaultPrecision {
+		t.Fatalf("expected the default digits but got %d", digits)
+	}
+}
@@ -0,0 +1,53 @@
+package compute
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultPrecision is the number of decimals of the computed metrics, as most
+// of them are ratios.
+const DefaultPrecision = 2
+
+var keyRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Metric is a named metric computed from the metrics of the fetched tabs.
+type Metric struct {
+	// Key is the JSON key of the metric, e.g. issue_closure_ratio.
+	Key string `json:"key" yaml:"key"`
+	// Name is the human readable name. Default is derived from the key.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Expr is the expression computing the metric from the JSON keys of the
+	// metrics of all the tabs.
+	Expr string `json:"expr" yaml:"expr"`
+	// Precision is the number of decimals to render. Default is 2.
+	Precision *int `json:"precision,omitempty" yaml:"precision,omitempty"`
+}
+
+// Validate checks that the metric has a valid key and expression.
+func (m Metric) Validate() error {
+	if !ValidKey(m.Key) {
+		return fmt.Errorf("invalid computed metric key %q: it must be lowercase letters, digits and underscores", m.Key)
+	}
+
+	if _, err := Parse(m.Expr); err != nil {
+		return fmt.Errorf("computed metric %s: %w", m.Key, err)
+	}
+
+	return nil
+}
+
+// ValidKey returns true if the key is valid for a computed metric, so it can
+// be referenced in the expressions.
+func ValidKey(key string) bool {
+	return keyRegexp.MatchString(key)
+}
+
+// Digits returns the number of decimals to render the metric.
+func (m Metric) Digits() int {
+	if m.Precision == nil {
+		return DefaultPrecision
+	}
+
+	return *m.Precision
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"github.com/mdelapenya/cauldrongo/compute"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+	"github.com/mdelapenya/cauldrongo/score"
+)
+
+// Config is the content of the configuration file.
+type Config struct {
+	// Include are the paths of other configuration files, e.g. with shared
+	// project lists, relative to the including file, which overrides them.
+	Include []string `mapstructure:"include" yaml:"include,omitempty"`
+
+	// BaseURL is the base URL of the Cauldron instance, e.g. a self-hosted one.
+	BaseURL string `mapstructure:"base_url" yaml:"base_url,omitempty"`
+	// Token is the API token of a Cauldron user, usually read from an
+	// environment variable with ${VAR}.
+	Token string `mapstructure:"token" yaml:"token,omitempty"`
+	// Concurrency is the maximum number of concurrent requests to Cauldron.
+	Concurrency int `mapstructure:"concurrency" yaml:"concurrency,omitempty"`
+
+	Projects []project.Project `mapstructure:"projects" yaml:"projects,omitempty"`
+	Groups   []project.Group   `mapstructure:"groups" yaml:"groups,omitempty"`
+	// Computed are the metrics computed with expressions from the fetched
+	// tabs, printed in the computed pseudo-tab.
+	Computed []compute.Metric `mapstructure:"computed" yaml:"computed,omitempty"`
+	// Rules are the thresholds checked by the check command.
+	Rules []rule.Rule `mapstructure:"rules" yaml:"rules,omitempty"`
+	// Score are the weighted metrics of the health score of the score command.
+	Score []score.Metric `mapstructure:"score" yaml:"score,omitempty"`
+}
+
+// WithDefaults returns a copy of the configuration, with the settings of the
+// default project in the unset settings of the projects.
+func (c Config) WithDefaults(defaults project.Project) Config {
+	effective := c
+	effective.Projects = make([]project.Project, len(c.Projects))
+
+	for i, p := range c.Projects {
+		if p.From == "" {
+			p.From = defaults.From
+		}
+
+		// the named ranges end the period when there's no end date
+		if p.To == "" && period.IsRange(p.From) {
+			p.To = p.From
+		} else if p.To == "" {
+			p.To = defaults.To
+		}
+
+		if len(p.Tabs) == 0 {
+			p.Tabs = defaults.Tabs
+		}
+
+		if p.Format == "" {
+			p.Format = defaults.Format
+		}
+
+		effective.Projects[i] = p
+	}
+
+	return effective
+}
@@ -0,0 +1,74 @@
+package config_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/compute"
+	"github.com/mdelapenya/cauldrongo/config"
+	"github.com/mdelapenya/cauldrongo/goal"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+	"github.com/mdelapenya/cauldrongo/score"
+)
+
+func TestWithDefaults(t *testing.T) {
+	weight := 2.0
+
+	c := config.Config{
+		BaseURL:     "https://cauldron.example.com",
+		Token:       "secret",
+		Concurrency: 4,
+		Projects: []project.Project{
+			{ID: 2296, Name: "testcontainers-go", From: "30d", Goals: []goal.Goal{{Metric: "commits_overview", Target: 100, From: "2024-01-01", Deadline: "2024-12-31"}}},
+			{ID: 7264, Name: "testcontainers-java", Tabs: []string{"overview"}, Labels: map[string]string{"lang": "java"}},
+			{ID: 1234, Name: "testcontainers-dotnet", From: "last-quarter"},
+		},
+		Groups:   []project.Group{{Name: "testcontainers", Projects: []string{"testcontainers-go", "7264"}}},
+		Computed: []compute.Metric{{Key: "issue_closure_ratio", Expr: "issues_closed_overview / issues_created_overview"}},
+		Rules:    []rule.Rule{{Name: "slow-reviews", Warn: "reviews_median_time_to_close_overview > 7", Projects: []string{"testcontainers-go"}}},
+		Score:    []score.Metric{{Key: "commits_overview", Weight: &weight}},
+	}
+
+	effective := c.WithDefaults(project.Project{From: "1y", To: "today", Tabs: []string{"overview", "activity-overview"}, Format: "console"})
+
+	expected := c
+	expected.Projects = []project.Project{c.Projects[0], c.Projects[1], c.Projects[2]}
+	expected.Projects[0].To = "today"
+	expected.Projects[0].Tabs = []string{"overview", "activity-overview"}
+	expected.Projects[0].Format = "console"
+	expected.Projects[1].From = "1y"
+	expected.Projects[1].To = "today"
+	expected.Projects[1].Format = "console"
+	// the named ranges end the period
+	expected.Projects[2].To = "last-quarter"
+	expected.Projects[2].Tabs = []string{"overview", "activity-overview"}
+	expected.Projects[2].Format = "console"
+
+	if !reflect.DeepEqual(effective, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, effective)
+	}
+
+	if c.Projects[1].From != "" {
+		t.Fatal("expected the projects of the configuration to be unchanged")
+	}
+
+	// every section survives writing the effective configuration and loading it back
+	bs, err := config.Marshal(effective)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), ".cauldrongo.yml")
+	write(t, path, string(bs))
+
+	loaded, _, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(loaded, effective) {
+		t.Fatalf("expected %+v but got %+v", effective, loaded)
+	}
+}
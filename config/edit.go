@@ -0,0 +1,365 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+// File is a configuration file being edited, keeping the comments and the
+// order of the keys when written back.
+type File struct {
+	doc *yaml.Node
+	// compact tells, for the lists of the file, whether their items are at
+	// the same indentation as their key (- id:) or indented (  - id:)
+	compact map[*yaml.Node]bool
+	// compactAt is the indentation of the first list at each depth of the
+	// file, used for the lists added by the edits
+	compactAt map[int]bool
+}
+
+// ParseFile parses the content of a configuration file to edit it.
+func ParseFile(bs []byte) (*File, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(bs, doc); err != nil {
+		return nil, fmt.Errorf("error parsing the configuration file: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		// the file is empty, or only has comments
+		comment := doc.HeadComment
+		doc = &yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: comment}},
+		}
+	}
+
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("error parsing the configuration file: the root must be a mapping")
+	}
+
+	f := &File{doc: doc, compact: map[*yaml.Node]bool{}, compactAt: map[int]bool{}}
+	f.detectIndentation(doc.Content[0], 0)
+
+	return f, nil
+}
+
+// Bytes returns the content of the edited configuration file, keeping the
+// indentation of its lists.
+func (f *File) Bytes() ([]byte, error) {
+	bs, err := Marshal(f.doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// the encoder indents all the lists, so the compact ones are dedented,
+	// locating them in the encoded content
+	encoded := &yaml.Node{}
+	if err := yaml.Unmarshal(bs, encoded); err != nil {
+		return nil, fmt.Errorf("error encoding the configuration: %w", err)
+	}
+
+	lines := strings.Split(string(bs), "\n")
+	dedents := make([]int, len(lines)+1)
+	f.dedent(f.root(), encoded.Content[0], 0, len(lines)+1, dedents)
+
+	for i := range lines {
+		n := dedents[i+1]
+		for n > 0 && strings.HasPrefix(lines[i], " ") {
+			lines[i] = lines[i][1:]
+			n--
+		}
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// detectIndentation records the indentation of the lists under the node,
+// which is at the given depth of mappings.
+func (f *File) detectIndentation(node *yaml.Node, depth int) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if value.Kind == yaml.SequenceNode && value.Style&yaml.FlowStyle == 0 && len(value.Content) > 0 {
+				// the column of an item is after its dash
+				compact := value.Content[0].Column-2 <= key.Column
+				f.compact[value] = compact
+
+				if _, ok := f.compactAt[depth]; !ok {
+					f.compactAt[depth] = compact
+				}
+			}
+
+			f.detectIndentation(value, depth+1)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			f.detectIndentation(item, depth)
+		}
+	}
+}
+
+// dedent walks the edited node and the same node of the encoded content,
+// which spans the lines up to end, adding the indentation to remove from
+// each line of its compact lists.
+func (f *File) dedent(node *yaml.Node, encoded *yaml.Node, depth int, end int, dedents []int) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, encodedValue := node.Content[i+1], encoded.Content[i+1]
+
+			next := end
+			if i+2 < len(encoded.Content) {
+				next = firstLine(encoded.Content[i+2])
+			}
+
+			if value.Kind == yaml.SequenceNode && value.Style&yaml.FlowStyle == 0 && len(value.Content) > 0 {
+				compact, ok := f.compact[value]
+				if !ok {
+					compact = f.compactAt[depth]
+				}
+
+				if compact {
+					for line := firstLine(encodedValue.Content[0]); line < next && line < len(dedents); line++ {
+						dedents[line] += 2
+					}
+				}
+			}
+
+			f.dedent(value, encodedValue, depth+1, next, dedents)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			next := end
+			if i+1 < len(encoded.Content) {
+				next = firstLine(encoded.Content[i+1])
+			}
+
+			f.dedent(item, encoded.Content[i], depth, next, dedents)
+		}
+	}
+}
+
+// firstLine returns the line where an encoded node starts, including its
+// head comments.
+func firstLine(node *yaml.Node) int {
+	line := node.Line
+	if node.HeadComment != "" {
+		line -= strings.Count(node.HeadComment, "\n") + 1
+	}
+
+	if node.Kind == yaml.MappingNode && len(node.Content) > 0 {
+		line = min(line, firstLine(node.Content[0]))
+	}
+
+	return line
+}
+
+// Marshal encodes a value as YAML, indented with two spaces.
+func Marshal(v any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("error encoding the configuration: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("error encoding the configuration: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// AddProject appends a project to the configuration file, failing if there is
+// already a project with the same ID or name.
+func (f *File) AddProject(p project.Project) error {
+	projects := f.sequence("projects")
+
+	for _, n := range projects.Content {
+		if matchRef(n, strconv.Itoa(p.ID)) {
+			return fmt.Errorf("project %d is already configured", p.ID)
+		}
+
+		if p.Name != "" && matchRef(n, p.Name) {
+			return fmt.Errorf("project %s is already configured", p.Name)
+		}
+	}
+
+	node := &yaml.Node{}
+	if err := node.Encode(p); err != nil {
+		return fmt.Errorf("error encoding the project: %w", err)
+	}
+
+	projects.Content = append(projects.Content, node)
+
+	return nil
+}
+
+// RemoveProject removes the project with the given name or ID from the
+// configuration file, and from the groups referencing it.
+func (f *File) RemoveProject(ref string) error {
+	projects := mappingValue(f.root(), "projects")
+	if projects == nil || projects.Kind != yaml.SequenceNode {
+		return fmt.Errorf("project %s is not configured", ref)
+	}
+
+	i := findRef(projects, ref)
+	if i < 0 {
+		return fmt.Errorf("project %s is not configured", ref)
+	}
+
+	removed := projects.Content[i]
+	projects.Content = append(projects.Content[:i], projects.Content[i+1:]...)
+
+	refs := map[string]bool{
+		scalarValue(mappingValue(removed, "id")):   true,
+		scalarValue(mappingValue(removed, "name")): true,
+	}
+
+	groups := mappingValue(f.root(), "groups")
+	if groups == nil || groups.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for _, g := range groups.Content {
+		members := mappingValue(g, "projects")
+		if members == nil || members.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		kept := []*yaml.Node{}
+		for _, m := range members.Content {
+			if !refs[m.Value] {
+				kept = append(kept, m)
+			}
+		}
+		members.Content = kept
+	}
+
+	return nil
+}
+
+// Set sets the value of a key of the configuration file, creating it if
+// missing. The key is a dotted path, where the projects and the groups are
+// identified by their name or ID, e.g. projects.testcontainers-go.from. The
+// value is parsed as YAML, so lists are written as [a, b].
+func (f *File) Set(key string, value string) error {
+	segments := strings.Split(key, ".")
+	for _, s := range segments {
+		if s == "" {
+			return fmt.Errorf("invalid key %q", key)
+		}
+	}
+
+	parsed := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(value), parsed); err != nil {
+		return fmt.Errorf("invalid value %q: %w", value, err)
+	}
+
+	v := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: ""}
+	if len(parsed.Content) > 0 {
+		v = parsed.Content[0]
+		// block style, as in the rest of the file
+		v.Style &^= yaml.FlowStyle
+	}
+
+	node := f.root()
+	for i, s := range segments {
+		last := i == len(segments)-1
+		path := strings.Join(segments[:i+1], ".")
+
+		switch node.Kind {
+		case yaml.MappingNode:
+			current := mappingValue(node, s)
+			if last {
+				if current != nil {
+					// keep the comments of the replaced value
+					v.LineComment = current.LineComment
+					*current = *v
+				} else {
+					node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}, v)
+				}
+
+				return nil
+			}
+
+			if current == nil {
+				current = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}, current)
+			}
+
+			node = current
+		case yaml.SequenceNode:
+			j := findRef(node, s)
+			if j < 0 {
+				return fmt.Errorf("invalid key %q: %s is not configured", key, path)
+			}
+
+			if last {
+				*node.Content[j] = *v
+				return nil
+			}
+
+			node = node.Content[j]
+		default:
+			return fmt.Errorf("invalid key %q: %s is not a mapping or a list", key, strings.Join(segments[:i], "."))
+		}
+	}
+
+	return nil
+}
+
+func (f *File) root() *yaml.Node {
+	return f.doc.Content[0]
+}
+
+// sequence returns the sequence of a root key, creating it if missing.
+func (f *File) sequence(key string) *yaml.Node {
+	root := f.root()
+
+	node := mappingValue(root, key)
+	if node == nil {
+		node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, node)
+	}
+
+	if node.Kind != yaml.SequenceNode || node.Tag == "!!null" {
+		*node = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", HeadComment: node.HeadComment, LineComment: node.LineComment}
+	}
+
+	// block style, instead of the flow style of an empty list, e.g. projects: []
+	node.Style = 0
+
+	return node
+}
+
+// findRef returns the index of the item of a sequence identified by the
+// reference, which is its name or its ID, or -1.
+func findRef(seq *yaml.Node, ref string) int {
+	if ref == "" {
+		return -1
+	}
+
+	for i, n := range seq.Content {
+		if matchRef(n, ref) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// matchRef returns true if the name or the ID of the item is the reference.
+func matchRef(item *yaml.Node, ref string) bool {
+	return scalarValue(mappingValue(item, "id")) == ref || scalarValue(mappingValue(item, "name")) == ref
+}
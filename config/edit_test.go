@@ -0,0 +1,306 @@
+package config_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/config"
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+const content = `# the projects
+projects:
+  - id: 2296 # testcontainers-go
+    name: testcontainers-go
+  - id: 7264
+    name: testcontainers-java
+groups:
+  - name: testcontainers
+    projects:
+      - testcontainers-go
+      - "7264"
+`
+
+func TestFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		edit     func(f *config.File) error
+		expected string
+	}{
+		{
+			name: "add-project",
+			edit: func(f *config.File) error {
+				return f.AddProject(project.Project{ID: 7265, Name: "testcontainers-dotnet", Labels: map[string]string{"lang": "dotnet"}})
+			},
+			expected: `# the projects
+projects:
+  - id: 2296 # testcontainers-go
+    name: testcontainers-go
+  - id: 7264
+    name: testcontainers-java
+  - id: 7265
+    name: testcontainers-dotnet
+    labels:
+      lang: dotnet
+groups:
+  - name: testcontainers
+    projects:
+      - testcontainers-go
+      - "7264"
+`,
+		},
+		{
+			name: "remove-project-by-id",
+			edit: func(f *config.File) error {
+				return f.RemoveProject("7264")
+			},
+			expected: `# the projects
+projects:
+  - id: 2296 # testcontainers-go
+    name: testcontainers-go
+groups:
+  - name: testcontainers
+    projects:
+      - testcontainers-go
+`,
+		},
+		{
+			name: "remove-project-by-name",
+			edit: func(f *config.File) error {
+				return f.RemoveProject("testcontainers-go")
+			},
+			expected: `# the projects
+projects:
+  - id: 7264
+    name: testcontainers-java
+groups:
+  - name: testcontainers
+    projects:
+      - "7264"
+`,
+		},
+		{
+			name: "set",
+			edit: func(f *config.File) error {
+				if err := f.Set("projects.testcontainers-go.from", "last-quarter"); err != nil {
+					return err
+				}
+
+				if err := f.Set("projects.7264.tabs", "[overview, community-overview]"); err != nil {
+					return err
+				}
+
+				return f.Set("projects.7264.thresholds.open_issues_performance_overview", "100")
+			},
+			expected: `# the projects
+projects:
+  - id: 2296 # testcontainers-go
+    name: testcontainers-go
+    from: last-quarter
+  - id: 7264
+    name: testcontainers-java
+    tabs:
+      - overview
+      - community-overview
+    thresholds:
+      open_issues_performance_overview: 100
+groups:
+  - name: testcontainers
+    projects:
+      - testcontainers-go
+      - "7264"
+`,
+		},
+		{
+			name: "set-date",
+			edit: func(f *config.File) error {
+				return f.Set("projects.testcontainers-go.from", "2024-01-01")
+			},
+			expected: `# the projects
+projects:
+  - id: 2296 # testcontainers-go
+    name: testcontainers-go
+    from: 2024-01-01
+  - id: 7264
+    name: testcontainers-java
+groups:
+  - name: testcontainers
+    projects:
+      - testcontainers-go
+      - "7264"
+`,
+		},
+		{
+			name: "set-existing",
+			edit: func(f *config.File) error {
+				return f.Set("projects.testcontainers-go.id", "2297")
+			},
+			expected: `# the projects
+projects:
+  - id: 2297 # testcontainers-go
+    name: testcontainers-go
+  - id: 7264
+    name: testcontainers-java
+groups:
+  - name: testcontainers
+    projects:
+      - testcontainers-go
+      - "7264"
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			f, err := config.ParseFile([]byte(content))
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if err := testCase.edit(f); err != nil {
+				tt.Fatal(err)
+			}
+
+			bs, err := f.Bytes()
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if string(bs) != testCase.expected {
+				tt.Errorf("expected:\n%s\ngot:\n%s", testCase.expected, string(bs))
+			}
+
+			// the edited files stay valid
+			issues, err := config.Validate(bs, time.Now(), nil)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if len(issues) != 0 {
+				tt.Errorf("expected no issues, got %v", issues)
+			}
+		})
+	}
+}
+
+func TestFileKeepsIndentation(t *testing.T) {
+	// the sample doesn't indent the projects and the groups, but indents
+	// their repository URLs and members
+	bs, err := os.ReadFile("../.sample-cauldrongo.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample := string(bs)
+
+	f, err := config.ParseFile(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.AddProject(project.Project{ID: 7608, Name: "testcontainers-python", RepoURL: []string{"https://github.com/testcontainers/testcontainers-python"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Set("projects.testcontainers-go.tabs", "[overview, activity-overview]"); err != nil {
+		t.Fatal(err)
+	}
+
+	edited, err := f.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Replace(sample, `    lang: go
+`, `    lang: go
+  tabs:
+    - overview
+    - activity-overview
+`, 1)
+	expected = strings.Replace(expected, `groups:
+`, `- id: 7608
+  name: testcontainers-python
+  repo_url:
+    - https://github.com/testcontainers/testcontainers-python
+groups:
+`, 1)
+
+	if string(edited) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, string(edited))
+	}
+}
+
+func TestFileErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		edit func(f *config.File) error
+	}{
+		{
+			name: "add-duplicate-id",
+			edit: func(f *config.File) error {
+				return f.AddProject(project.Project{ID: 2296})
+			},
+		},
+		{
+			name: "add-duplicate-name",
+			edit: func(f *config.File) error {
+				return f.AddProject(project.Project{ID: 1, Name: "testcontainers-java"})
+			},
+		},
+		{
+			name: "remove-unknown",
+			edit: func(f *config.File) error {
+				return f.RemoveProject("testcontainers-ruby")
+			},
+		},
+		{
+			name: "set-unknown-project",
+			edit: func(f *config.File) error {
+				return f.Set("projects.testcontainers-ruby.from", "30d")
+			},
+		},
+		{
+			name: "set-invalid-key",
+			edit: func(f *config.File) error {
+				return f.Set("projects..from", "30d")
+			},
+		},
+		{
+			name: "set-scalar-path",
+			edit: func(f *config.File) error {
+				return f.Set("projects.2296.name.first", "testcontainers")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			f, err := config.ParseFile([]byte(content))
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if err := testCase.edit(f); err == nil {
+				tt.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	issues, err := config.Validate([]byte(config.Template), time.Now(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(issues) > 0 {
+		t.Errorf("expected a valid template, got %v", issues)
+	}
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of the environment variables setting the flags.
+const EnvPrefix = "CAULDRONGO"
+
+var variable = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// EnvVar returns the environment variable setting a flag, e.g.
+// CAULDRONGO_REPO_URL for --repo-url.
+func EnvVar(flag string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// Interpolate replaces the ${VAR} references in the values of a
+// configuration file with the values of the environment variables, or with
+// an empty string if they are not set. Other uses of $ are kept as is. The
+// replaced values are never parsed as YAML, so they can hold any character.
+func Interpolate(bs []byte) ([]byte, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(bs, doc); err != nil {
+		return nil, fmt.Errorf("error parsing the configuration file: %w", err)
+	}
+
+	// an empty file, or with only comments, has nothing to replace
+	if len(doc.Content) == 0 {
+		return bs, nil
+	}
+
+	interpolateNode(doc)
+
+	return Marshal(doc)
+}
+
+// interpolateNode replaces the ${VAR} references in the scalar values under
+// the node, resolving again the type of the plain ones, e.g. id: ${ID}.
+func interpolateNode(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if !variable.MatchString(node.Value) {
+			return
+		}
+
+		node.Value = variable.ReplaceAllStringFunc(node.Value, func(ref string) string {
+			return os.Getenv(variable.FindStringSubmatch(ref)[1])
+		})
+
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Tag = ""
+			node.Tag = node.ShortTag()
+		}
+	case yaml.MappingNode:
+		// only the values, as the keys are part of the schema
+		for i := 1; i < len(node.Content); i += 2 {
+			interpolateNode(node.Content[i])
+		}
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			interpolateNode(n)
+		}
+	}
+}
+
+// Variables returns the names of the environment variables referenced with
+// ${VAR} in a value.
+func Variables(s string) []string {
+	names := []string{}
+	for _, m := range variable.FindAllStringSubmatch(s, -1) {
+		names = append(names, m[1])
+	}
+
+	return names
+}
+
+// Leaf is a scalar value of a configuration file, or a list of them.
+type Leaf struct {
+	// Path is the dotted path of the value, where the projects and the groups
+	// are identified by their name or ID, as in the config set command.
+	Path  string
+	Value string
+}
+
+// Leaves returns the values of a configuration file, in order.
+func Leaves(bs []byte) ([]Leaf, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(bs, doc); err != nil {
+		return nil, fmt.Errorf("error parsing the configuration file: %w", err)
+	}
+
+	leaves := []Leaf{}
+	if len(doc.Content) > 0 {
+		collectLeaves(doc.Content[0], "", &leaves)
+	}
+
+	return leaves, nil
+}
+
+func collectLeaves(node *yaml.Node, path string, leaves *[]Leaf) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			collectLeaves(node.Content[i+1], join(path, node.Content[i].Value), leaves)
+		}
+	case yaml.SequenceNode:
+		values := []string{}
+		for i, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				values = append(values, item.Value)
+				continue
+			}
+
+			ref := scalarValue(mappingValue(item, "name"))
+			if ref == "" {
+				ref = scalarValue(mappingValue(item, "id"))
+			}
+
+			if ref == "" {
+				ref = strconv.Itoa(i)
+			}
+
+			collectLeaves(item, join(path, ref), leaves)
+		}
+
+		if len(values) > 0 {
+			*leaves = append(*leaves, Leaf{Path: path, Value: strings.Join(values, ", ")})
+		}
+	case yaml.ScalarNode:
+		*leaves = append(*leaves, Leaf{Path: path, Value: node.Value})
+	case yaml.AliasNode:
+		collectLeaves(node.Alias, path, leaves)
+	}
+}
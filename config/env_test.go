@@ -0,0 +1,118 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/config"
+)
+
+func TestEnvVar(t *testing.T) {
+	testCases := []struct {
+		flag     string
+		expected string
+	}{
+		{flag: "from", expected: "CAULDRONGO_FROM"},
+		{flag: "repo-url", expected: "CAULDRONGO_REPO_URL"},
+		{flag: "base-url", expected: "CAULDRONGO_BASE_URL"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.flag, func(tt *testing.T) {
+			tt.Parallel()
+
+			if got := config.EnvVar(testCase.flag); got != testCase.expected {
+				tt.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("CAULDRON_TOKEN", "abc#def")
+	t.Setenv("GO_FROM", "last-quarter")
+	t.Setenv("GO_ID", "2296")
+	t.Setenv("GO_NAME", "go #1: testcontainers")
+
+	content := `token: ${CAULDRON_TOKEN}
+projects:
+  - id: ${GO_ID}
+    name: ${GO_NAME}
+    from: ${GO_FROM}
+    to: ${UNSET_CAULDRONGO_VARIABLE}
+    output: reports/$name.md
+`
+	expected := `token: abc#def
+projects:
+  - id: 2296
+    name: 'go #1: testcontainers'
+    from: last-quarter
+    to:
+    output: reports/$name.md
+`
+
+	got, err := config.Interpolate([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, string(got))
+	}
+
+	t.Run("invalid", func(tt *testing.T) {
+		if _, err := config.Interpolate([]byte("token: [")); err == nil {
+			tt.Error("expected an error")
+		}
+	})
+}
+
+func TestVariables(t *testing.T) {
+	vars := config.Variables("${A}-${B_2} $C")
+	if len(vars) != 2 || vars[0] != "A" || vars[1] != "B_2" {
+		t.Errorf("expected [A B_2], got %v", vars)
+	}
+}
+
+func TestLeaves(t *testing.T) {
+	content := `token: ${CAULDRON_TOKEN}
+projects:
+  - id: 2296
+    name: testcontainers-go
+    repo_url:
+      - https://github.com/testcontainers/testcontainers-go
+      - https://github.com/testcontainers/testcontainers-go.git
+    thresholds:
+      open_issues_performance_overview: 100
+  - id: 7264
+groups:
+  - name: testcontainers
+    projects: [testcontainers-go, 7264]
+`
+
+	expected := []config.Leaf{
+		{Path: "token", Value: "${CAULDRON_TOKEN}"},
+		{Path: "projects.testcontainers-go.id", Value: "2296"},
+		{Path: "projects.testcontainers-go.name", Value: "testcontainers-go"},
+		{Path: "projects.testcontainers-go.repo_url", Value: "https://github.com/testcontainers/testcontainers-go, https://github.com/testcontainers/testcontainers-go.git"},
+		{Path: "projects.testcontainers-go.thresholds.open_issues_performance_overview", Value: "100"},
+		{Path: "projects.7264.id", Value: "7264"},
+		{Path: "groups.testcontainers.name", Value: "testcontainers"},
+		{Path: "groups.testcontainers.projects", Value: "testcontainers-go, 7264"},
+	}
+
+	leaves, err := config.Leaves([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(leaves) != len(expected) {
+		t.Fatalf("expected %d leaves, got %d: %v", len(expected), len(leaves), leaves)
+	}
+
+	for i, l := range leaves {
+		if l != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], l)
+		}
+	}
+}
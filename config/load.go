@@ -0,0 +1,263 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+
+	"github.com/mdelapenya/cauldrongo/compute"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+	"github.com/mdelapenya/cauldrongo/score"
+)
+
+// DefaultFile is the name of the configuration file in the working and the
+// home directories.
+const DefaultFile = ".cauldrongo.yml"
+
+// Candidates returns the paths where the configuration file is searched, by
+// priority: the working directory, $XDG_CONFIG_HOME/cauldrongo/config.yml,
+// which defaults to ~/.config, and the home directory.
+func Candidates(home string, xdgConfigHome string) []string {
+	if xdgConfigHome == "" && home != "" {
+		xdgConfigHome = filepath.Join(home, ".config")
+	}
+
+	candidates := []string{DefaultFile}
+	if xdgConfigHome != "" {
+		candidates = append(candidates, filepath.Join(xdgConfigHome, "cauldrongo", "config.yml"))
+	}
+
+	if home != "" {
+		candidates = append(candidates, filepath.Join(home, DefaultFile))
+	}
+
+	return candidates
+}
+
+// Discover returns the existing configuration files, by priority. An
+// explicit file is the only one used. Otherwise, it's the first of the
+// candidates found, or all of them when merging the layers.
+func Discover(explicit string, candidates []string, merge bool) []string {
+	if explicit != "" {
+		if exists(explicit) {
+			return []string{explicit}
+		}
+
+		return []string{}
+	}
+
+	found := []string{}
+	for _, c := range candidates {
+		if !exists(c) {
+			continue
+		}
+
+		found = append(found, c)
+		if !merge {
+			break
+		}
+	}
+
+	return found
+}
+
+// Load reads a configuration file, replacing the ${VAR} references with the
+// environment variables, and the files it includes, which the including file
+// overrides. It returns the merged configuration, and the paths of the files
+// read by priority.
+func Load(path string) (Config, []string, error) {
+	return load(path, map[string]bool{})
+}
+
+func load(path string, visiting map[string]bool) (Config, []string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("error reading the configuration file %s: %w", path, err)
+	}
+
+	if visiting[abs] {
+		return Config{}, nil, fmt.Errorf("error reading the configuration file %s: include cycle", path)
+	}
+	visiting[abs] = true
+	defer delete(visiting, abs)
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("error reading the configuration file: %w", err)
+	}
+
+	interpolated, err := Interpolate(bs)
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("error reading the configuration file %s: %w", path, err)
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(interpolated)); err != nil {
+		return Config{}, nil, fmt.Errorf("error reading the configuration file %s: %w", path, err)
+	}
+
+	c := Config{}
+	if err := v.Unmarshal(&c, viper.DecodeHook(decodeHook)); err != nil {
+		return Config{}, nil, fmt.Errorf("error decoding the configuration file %s: %w", path, err)
+	}
+
+	merged := Config{}
+	files := []string{path}
+	for _, include := range c.Include {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+
+		included, includedFiles, err := load(include, visiting)
+		if err != nil {
+			return Config{}, nil, err
+		}
+
+		merged = Merge(merged, included)
+		files = append(files, includedFiles...)
+	}
+
+	c.Include = nil
+
+	return Merge(merged, c), files, nil
+}
+
+// Merge returns the base configuration overridden by another one: the global
+// settings set in the override replace the base ones, and so do the projects
+// with the same ID, the groups and rules with the same name and the computed
+// metrics with the same key, while the rest are appended.
+func Merge(base Config, override Config) Config {
+	merged := base
+
+	if override.BaseURL != "" {
+		merged.BaseURL = override.BaseURL
+	}
+
+	if override.Token != "" {
+		merged.Token = override.Token
+	}
+
+	if override.Concurrency != 0 {
+		merged.Concurrency = override.Concurrency
+	}
+
+	merged.Projects = append([]project.Project{}, base.Projects...)
+	for _, p := range override.Projects {
+		replaced := false
+		for i := range merged.Projects {
+			if merged.Projects[i].ID == p.ID {
+				merged.Projects[i] = p
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			merged.Projects = append(merged.Projects, p)
+		}
+	}
+
+	merged.Groups = append([]project.Group{}, base.Groups...)
+	for _, g := range override.Groups {
+		replaced := false
+		for i := range merged.Groups {
+			if merged.Groups[i].Name == g.Name {
+				merged.Groups[i] = g
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			merged.Groups = append(merged.Groups, g)
+		}
+	}
+
+	merged.Computed = append([]compute.Metric{}, base.Computed...)
+	for _, m := range override.Computed {
+		replaced := false
+		for i := range merged.Computed {
+			if merged.Computed[i].Key == m.Key {
+				merged.Computed[i] = m
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			merged.Computed = append(merged.Computed, m)
+		}
+	}
+
+	merged.Rules = append([]rule.Rule{}, base.Rules...)
+	for _, r := range override.Rules {
+		replaced := false
+		for i := range merged.Rules {
+			if merged.Rules[i].Name == r.Name {
+				merged.Rules[i] = r
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			merged.Rules = append(merged.Rules, r)
+		}
+	}
+
+	merged.Score = append([]score.Metric{}, base.Score...)
+	for _, m := range override.Score {
+		replaced := false
+		for i := range merged.Score {
+			if merged.Score[i].Key == m.Key {
+				merged.Score[i] = m
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			merged.Score = append(merged.Score, m)
+		}
+	}
+
+	return merged
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// decodeHook extends the default hooks of viper decoding the unquoted dates,
+// which YAML parses as timestamps, into the strings of the date settings.
+var decodeHook = mapstructure.ComposeDecodeHookFunc(
+	mapstructure.StringToTimeDurationHookFunc(),
+	mapstructure.StringToSliceHookFunc(","),
+	timeToString,
+)
+
+// timeToString formats a timestamp decoded into a string as a date, or with
+// its time if it has one.
+func timeToString(_ reflect.Type, to reflect.Type, data any) (any, error) {
+	t, ok := data.(time.Time)
+	if !ok || to.Kind() != reflect.String {
+		return data, nil
+	}
+
+	if t.Equal(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())) {
+		return t.Format(period.Layout), nil
+	}
+
+	return t.Format(time.RFC3339), nil
+}
@@ -0,0 +1,287 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/config"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+	"github.com/mdelapenya/cauldrongo/score"
+)
+
+func write(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	testCases := []struct {
+		name     string
+		home     string
+		xdg      string
+		expected []string
+	}{
+		{
+			name:     "home",
+			home:     "/home/user",
+			expected: []string{".cauldrongo.yml", "/home/user/.config/cauldrongo/config.yml", "/home/user/.cauldrongo.yml"},
+		},
+		{
+			name:     "xdg",
+			home:     "/home/user",
+			xdg:      "/xdg",
+			expected: []string{".cauldrongo.yml", "/xdg/cauldrongo/config.yml", "/home/user/.cauldrongo.yml"},
+		},
+		{
+			name:     "no-home",
+			expected: []string{".cauldrongo.yml"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			candidates := config.Candidates(testCase.home, testCase.xdg)
+			if len(candidates) != len(testCase.expected) {
+				tt.Fatalf("expected %v, got %v", testCase.expected, candidates)
+			}
+
+			for i, c := range candidates {
+				if c != testCase.expected[i] {
+					tt.Errorf("expected %v, got %v", testCase.expected, candidates)
+				}
+			}
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+
+	local := filepath.Join(dir, "cwd", ".cauldrongo.yml")
+	xdg := filepath.Join(dir, "xdg", "cauldrongo", "config.yml")
+	home := filepath.Join(dir, "home", ".cauldrongo.yml")
+	write(t, local, "")
+	write(t, home, "")
+
+	candidates := []string{local, xdg, home}
+
+	testCases := []struct {
+		name     string
+		explicit string
+		merge    bool
+		expected []string
+	}{
+		{name: "first", expected: []string{local}},
+		{name: "merge", merge: true, expected: []string{local, home}},
+		{name: "explicit", explicit: home, merge: true, expected: []string{home}},
+		{name: "explicit-missing", explicit: xdg, expected: []string{}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			found := config.Discover(testCase.explicit, candidates, testCase.merge)
+			if len(found) != len(testCase.expected) {
+				tt.Fatalf("expected %v, got %v", testCase.expected, found)
+			}
+
+			for i, f := range found {
+				if f != testCase.expected[i] {
+					tt.Errorf("expected %v, got %v", testCase.expected, found)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	write(t, filepath.Join(dir, "shared", "projects.yml"), `concurrency: 4
+projects:
+  - id: 7264
+    name: testcontainers-java
+  - id: 2296
+    name: go
+    from: 30d
+groups:
+  - name: testcontainers
+    projects: [testcontainers-java]
+`)
+
+	main := filepath.Join(dir, ".cauldrongo.yml")
+	write(t, main, `include: [shared/projects.yml]
+concurrency: 2
+projects:
+  - id: 2296
+    name: testcontainers-go
+groups:
+  - name: testcontainers
+    projects: [testcontainers-java, testcontainers-go]
+`)
+
+	c, files, err := config.Load(main)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 2 || files[0] != main || files[1] != filepath.Join(dir, "shared", "projects.yml") {
+		t.Errorf("unexpected files %v", files)
+	}
+
+	if c.Concurrency != 2 {
+		t.Errorf("expected concurrency 2, got %d", c.Concurrency)
+	}
+
+	if len(c.Include) != 0 {
+		t.Errorf("expected no includes, got %v", c.Include)
+	}
+
+	if len(c.Projects) != 2 || c.Projects[0].ID != 7264 || c.Projects[1].Name != "testcontainers-go" || c.Projects[1].From != "" {
+		t.Errorf("unexpected projects %+v", c.Projects)
+	}
+
+	if len(c.Groups) != 1 || len(c.Groups[0].Projects) != 2 {
+		t.Errorf("unexpected groups %+v", c.Groups)
+	}
+}
+
+func TestLoadUnquotedDates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".cauldrongo.yml")
+	write(t, path, `projects:
+  - id: 2296
+    name: testcontainers-go
+    from: 2024-01-01
+    to: 2024-03-31T12:00:00Z
+    goals:
+      - metric: reviews_median_time_to_close_overview
+        target: 2
+        from: 2024-10-01
+        deadline: 2024-12-31
+`)
+
+	c, _, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := c.Projects[0]; p.From != "2024-01-01" || p.To != "2024-03-31T12:00:00Z" {
+		t.Errorf("expected the dates as strings, got %q and %q", p.From, p.To)
+	}
+
+	if g := c.Projects[0].Goals[0]; g.From != "2024-10-01" || g.Deadline != "2024-12-31" {
+		t.Errorf("expected the goal dates as strings, got %q and %q", g.From, g.Deadline)
+	}
+}
+
+func TestLoadInterpolatedDates(t *testing.T) {
+	t.Setenv("GO_FROM", "2024-01-01")
+	t.Setenv("GO_TO", "2024-03-31")
+
+	path := filepath.Join(t.TempDir(), ".cauldrongo.yml")
+	write(t, path, `projects:
+  - id: 2296
+    name: testcontainers-go
+    from: ${GO_FROM}
+    to: ${GO_TO}
+`)
+
+	c, _, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := c.Projects[0]; p.From != "2024-01-01" || p.To != "2024-03-31" {
+		t.Errorf("expected the dates as strings, got %q and %q", p.From, p.To)
+	}
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := config.Validate(bs, time.Now(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
+
+func TestLoadIncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+
+	write(t, filepath.Join(dir, "a.yml"), "include: [b.yml]\n")
+	write(t, filepath.Join(dir, "b.yml"), "include: [a.yml]\n")
+
+	if _, _, err := config.Load(filepath.Join(dir, "a.yml")); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	weight := 2.0
+
+	base := config.Config{
+		BaseURL: "https://cauldron.example.com",
+		Token:   "base",
+		Projects: []project.Project{
+			{ID: 2296, Name: "go"},
+			{ID: 7264, Name: "testcontainers-java"},
+		},
+		Groups: []project.Group{{Name: "testcontainers", Projects: []string{"go"}}},
+		Rules:  []rule.Rule{{Name: "slow-issues", Fail: "issues_median_time_to_close_overview > 30"}},
+		Score:  []score.Metric{{Key: "commits_overview"}, {Key: "issue_closure_ratio_derived"}},
+	}
+
+	override := config.Config{
+		Token:    "override",
+		Projects: []project.Project{{ID: 2296, Name: "testcontainers-go"}, {ID: 7265}},
+		Groups:   []project.Group{{Name: "dotnet", Projects: []string{"7265"}}},
+		Rules:    []rule.Rule{{Name: "slow-issues", Fail: "issues_median_time_to_close_overview > 60"}},
+		Score:    []score.Metric{{Key: "commits_overview", Weight: &weight}},
+	}
+
+	merged := config.Merge(base, override)
+
+	if merged.BaseURL != "https://cauldron.example.com" || merged.Token != "override" {
+		t.Errorf("unexpected global settings %+v", merged)
+	}
+
+	if len(merged.Projects) != 3 || merged.Projects[0].Name != "testcontainers-go" || merged.Projects[2].ID != 7265 {
+		t.Errorf("unexpected projects %+v", merged.Projects)
+	}
+
+	if len(merged.Groups) != 2 {
+		t.Errorf("unexpected groups %+v", merged.Groups)
+	}
+
+	if len(merged.Rules) != 1 || merged.Rules[0].Fail != "issues_median_time_to_close_overview > 60" {
+		t.Errorf("unexpected rules %+v", merged.Rules)
+	}
+
+	if len(merged.Score) != 2 || merged.Score[0].Weighting() != 2 {
+		t.Errorf("unexpected score metrics %+v", merged.Score)
+	}
+
+	if base.Projects[0].Name != "go" {
+		t.Errorf("the base configuration was modified")
+	}
+}
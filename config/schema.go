@@ -0,0 +1,12 @@
+package config
+
+import (
+	"reflect"
+
+	"github.com/mdelapenya/cauldrongo/schema"
+)
+
+// Schema returns the JSON Schema of the configuration file.
+func Schema() schema.Schema {
+	return schema.NewGenerator("yaml").Document(reflect.TypeOf(Config{}), "cauldrongo configuration", "The configuration file of cauldrongo, e.g. .cauldrongo.yml.")
+}
@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/config"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/schema"
+)
+
+// TestSchema checks that the schema of the configuration file has the same
+// keys the validation accepts.
+func TestSchema(t *testing.T) {
+	defs := config.Schema()["$defs"].(schema.Schema)
+
+	testCases := []struct {
+		name string
+		t    reflect.Type
+	}{
+		{name: "config.Config", t: reflect.TypeOf(config.Config{})},
+		{name: "project.Project", t: reflect.TypeOf(project.Project{})},
+		{name: "project.Group", t: reflect.TypeOf(project.Group{})},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			properties := defs[testCase.name].(schema.Schema)["properties"].(schema.Schema)
+
+			keys := config.Keys(testCase.t)
+			if len(properties) != len(keys) {
+				tt.Errorf("expected %d properties, got %d", len(keys), len(properties))
+			}
+
+			for k := range keys {
+				if _, ok := properties[k]; !ok {
+					tt.Errorf("missing property %s", k)
+				}
+			}
+		})
+	}
+}
+
+// TestSchemaMetrics checks that the computed metrics and the score metrics,
+// both named Metric in their packages, have their own definitions.
+func TestSchemaMetrics(t *testing.T) {
+	s := config.Schema()
+	defs := s["$defs"].(schema.Schema)
+	properties := defs["config.Config"].(schema.Schema)["properties"].(schema.Schema)
+
+	testCases := []struct {
+		section  string
+		def      string
+		accepted string
+		rejected string
+	}{
+		{section: "computed", def: "compute.Metric", accepted: "expr", rejected: "weight"},
+		{section: "score", def: "score.Metric", accepted: "weight", rejected: "expr"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.section, func(tt *testing.T) {
+			tt.Parallel()
+
+			items := properties[testCase.section].(schema.Schema)["items"].(schema.Schema)
+			if ref := items["$ref"]; ref != "#/$defs/"+testCase.def {
+				tt.Fatalf("expected a reference to %s, got %v", testCase.def, ref)
+			}
+
+			metric := defs[testCase.def].(schema.Schema)["properties"].(schema.Schema)
+			if _, ok := metric[testCase.accepted]; !ok {
+				tt.Errorf("expected the %s property", testCase.accepted)
+			}
+
+			if _, ok := metric[testCase.rejected]; ok {
+				tt.Errorf("unexpected %s property", testCase.rejected)
+			}
+		})
+	}
+}
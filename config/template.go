@@ -0,0 +1,70 @@
+package config
+
+// Template is the content of a new configuration file.
+const Template = `# Configuration file of cauldrongo, listing the Cauldron projects to fetch
+# metrics for. Check it with: cauldrongo config validate
+
+# The projects are selected with --project-id, --select and --label.
+projects:
+  - id: 2296
+    name: testcontainers-go
+    # The repository URLs to fetch the metrics of, instead of the whole project.
+    repo_url:
+      - https://github.com/testcontainers/testcontainers-go
+    # The optional settings below override the defaults of the flags, although
+    # a flag explicitly set always takes precedence.
+    # from: last-quarter
+    # to: ""
+    # tabs: [overview, community-overview]
+    # fields: [commits_overview, issues_median_time_to_close_overview]
+    # thresholds:
+    #   issues_median_time_to_close_overview: 30
+    # format: markdown
+    # output: reports/testcontainers-go.md
+    labels:
+      lang: go
+    # The goals are the target values tracked with the goals command, by a
+    # deadline, which defaults to the end of a named range.
+    # goals:
+    #   - name: Quarterly onboardings
+    #     metric: onboardings_git_community_overview
+    #     target: 10
+    #     from: this-quarter
+    #   - metric: reviews_median_time_to_close_overview
+    #     target: 2
+    #     from: 2024-10-01
+    #     deadline: 2024-12-31
+
+# The groups are families of projects, referenced by name or ID, whose metrics
+# are rolled up with --group.
+groups:
+  - name: testcontainers
+    projects:
+      - testcontainers-go
+
+# The computed metrics are expressions over the JSON keys of the metrics of all
+# the tabs, printed in the computed pseudo-tab.
+# computed:
+#   - key: issue_closure_ratio
+#     name: Issue closure ratio
+#     expr: issues_closed_activity_overview / issues_created_activity_overview
+#     precision: 2
+
+# The rules are the thresholds checked with the check command, as
+# <metric> <operator> <threshold>, failing or warning when met.
+# rules:
+#   - name: slow-issues
+#     warn: issues_median_time_to_close_overview > 20
+#     fail: issues_median_time_to_close_overview > 30
+#     # The names or the IDs of the checked projects. Default is all of them.
+#     projects: [testcontainers-go]
+
+# The score metrics are combined with their weights into the 0-100 health score
+# of the score command, normalized against their target or, without it,
+# between the worst and the best values of the projects.
+# score:
+#   - key: issue_closure_ratio_derived
+#     weight: 2
+#     target: 1
+#   - key: issues_median_time_to_close_overview
+`
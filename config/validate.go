@@ -0,0 +1,498 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mdelapenya/cauldrongo/compute"
+	"github.com/mdelapenya/cauldrongo/period"
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+)
+
+// Issue is a problem found validating a configuration file.
+type Issue struct {
+	Line    int
+	Message string
+}
+
+// Validate strictly decodes a configuration file, reporting the unknown keys,
+// the values of the wrong type, the duplicate projects, the malformed
+// repository URLs, the invalid dates and goals, the invalid computed metrics,
+// rules and score metrics, and the groups and rules referencing unknown
+// projects. The known projects, e.g. from other layers or included files, are
+// valid references too. The ${VAR} references are replaced before checking
+// the values, keeping the lines of the file. The error is only returned if the
+// file is not valid YAML.
+func Validate(bs []byte, now time.Time, known []project.Project) ([]Issue, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(bs, doc); err != nil {
+		return nil, fmt.Errorf("error parsing the configuration file: %w", err)
+	}
+
+	interpolateNode(doc)
+
+	// an empty file is a valid configuration
+	if len(doc.Content) == 0 {
+		return []Issue{}, nil
+	}
+
+	v := &validator{now: now, known: known, issues: []Issue{}}
+	root := doc.Content[0]
+
+	v.checkNode(root, reflect.TypeOf(Config{}), "")
+	v.checkProjects(mappingValue(root, "projects"))
+	v.checkGroups(mappingValue(root, "groups"), mappingValue(root, "projects"))
+	v.checkComputed(mappingValue(root, "computed"))
+	v.checkRules(mappingValue(root, "rules"), mappingValue(root, "projects"))
+	v.checkScore(mappingValue(root, "score"))
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		return v.issues[i].Line < v.issues[j].Line
+	})
+
+	return v.issues, nil
+}
+
+type validator struct {
+	now    time.Time
+	known  []project.Project
+	issues []Issue
+}
+
+func (v *validator) add(node *yaml.Node, format string, args ...any) {
+	v.issues = append(v.issues, Issue{Line: node.Line, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkNode checks that the node can be decoded into the given type,
+// reporting the unknown keys of the structs.
+func (v *validator) checkNode(node *yaml.Node, t reflect.Type, path string) {
+	if node == nil {
+		return
+	}
+
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	if node.Tag == "!!null" {
+		return
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, "%s must be a mapping", describe(path))
+			return
+		}
+
+		fields := Keys(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			field, ok := fields[key.Value]
+			if !ok {
+				v.add(key, "unknown key %q in %s", key.Value, describe(path))
+				continue
+			}
+
+			v.checkNode(value, field, join(path, key.Value))
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.add(node, "%s must be a list", describe(path))
+			return
+		}
+
+		for i, item := range node.Content {
+			v.checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.add(node, "%s must be a mapping", describe(path))
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.checkNode(node.Content[i+1], t.Elem(), join(path, node.Content[i].Value))
+		}
+	case reflect.Int:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.add(node, "%s must be an integer", describe(path))
+		}
+	case reflect.Float64:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			v.add(node, "%s must be a number", describe(path))
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.add(node, "%s must be a boolean", describe(path))
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			v.add(node, "%s must be a string", describe(path))
+		}
+	default:
+		if node.Kind != yaml.ScalarNode {
+			v.add(node, "%s must be a scalar", describe(path))
+		}
+	}
+}
+
+// checkProjects reports the duplicate projects, the malformed repository URLs,
+// the invalid dates and the invalid goals.
+func (v *validator) checkProjects(projects *yaml.Node) {
+	if projects == nil || projects.Kind != yaml.SequenceNode {
+		return
+	}
+
+	ids := map[string]int{}
+	names := map[string]int{}
+
+	for i, p := range projects.Content {
+		if p.Kind != yaml.MappingNode {
+			continue
+		}
+
+		path := fmt.Sprintf("projects[%d]", i)
+
+		if id := mappingValue(p, "id"); id != nil && id.Kind == yaml.ScalarNode {
+			if line, ok := ids[id.Value]; ok {
+				v.add(id, "duplicate project ID %s in %s, already defined at line %d", id.Value, path, line)
+			} else {
+				ids[id.Value] = id.Line
+			}
+		} else {
+			v.add(p, "missing project ID in %s", path)
+		}
+
+		if name := mappingValue(p, "name"); name != nil && name.Kind == yaml.ScalarNode && name.Value != "" {
+			if line, ok := names[name.Value]; ok {
+				v.add(name, "duplicate project name %q in %s, already defined at line %d", name.Value, path, line)
+			} else {
+				names[name.Value] = name.Line
+			}
+		}
+
+		if repos := mappingValue(p, "repo_url"); repos != nil && repos.Kind == yaml.SequenceNode {
+			for _, repo := range repos.Content {
+				if err := checkRepoURL(repo.Value); err != nil {
+					v.add(repo, "malformed repository URL %q in %s: %v", repo.Value, path, err)
+				}
+			}
+		}
+
+		from, to := scalarValue(mappingValue(p, "from")), scalarValue(mappingValue(p, "to"))
+		if from != "" || to != "" {
+			if _, err := period.Parse(from, to, v.now); err != nil {
+				node := mappingValue(p, "from")
+				if node == nil {
+					node = mappingValue(p, "to")
+				}
+
+				v.add(node, "invalid period in %s: %v", path, err)
+			}
+		}
+
+		v.checkGoals(mappingValue(p, "goals"), path)
+	}
+}
+
+// checkGoals reports the goals of a project without a metric or a target, and
+// with an invalid period.
+func (v *validator) checkGoals(goals *yaml.Node, path string) {
+	if goals == nil || goals.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for i, g := range goals.Content {
+		if g.Kind != yaml.MappingNode {
+			continue
+		}
+
+		goalPath := fmt.Sprintf("%s.goals[%d]", path, i)
+
+		if scalarValue(mappingValue(g, "metric")) == "" {
+			v.add(g, "missing goal metric in %s", goalPath)
+		}
+
+		if mappingValue(g, "target") == nil {
+			v.add(g, "missing goal target in %s", goalPath)
+		}
+
+		from, deadline := scalarValue(mappingValue(g, "from")), scalarValue(mappingValue(g, "deadline"))
+		if _, err := period.ParseWindow(from, deadline, v.now); err != nil {
+			node := mappingValue(g, "from")
+			if node == nil {
+				node = g
+			}
+
+			v.add(node, "invalid goal period in %s: %v", goalPath, err)
+		}
+	}
+}
+
+// checkGroups reports the groups referencing projects that are not configured.
+func (v *validator) checkGroups(groups *yaml.Node, projects *yaml.Node) {
+	if groups == nil || groups.Kind != yaml.SequenceNode {
+		return
+	}
+
+	known := v.knownRefs(projects)
+	for i, g := range groups.Content {
+		v.checkRefs(mappingValue(g, "projects"), known, fmt.Sprintf("groups[%d]", i))
+	}
+}
+
+// checkComputed reports the computed metrics with an invalid or duplicate key
+// and the invalid expressions.
+func (v *validator) checkComputed(computed *yaml.Node) {
+	if computed == nil || computed.Kind != yaml.SequenceNode {
+		return
+	}
+
+	keys := map[string]int{}
+
+	for i, m := range computed.Content {
+		if m.Kind != yaml.MappingNode {
+			continue
+		}
+
+		path := fmt.Sprintf("computed[%d]", i)
+
+		key := mappingValue(m, "key")
+		if key == nil || scalarValue(key) == "" {
+			v.add(m, "missing computed metric key in %s", path)
+		} else if line, ok := keys[key.Value]; ok {
+			v.add(key, "duplicate computed metric key %q in %s, already defined at line %d", key.Value, path, line)
+		} else {
+			keys[key.Value] = key.Line
+
+			if !compute.ValidKey(key.Value) {
+				v.add(key, "invalid computed metric key %q in %s: it must be lowercase letters, digits and underscores", key.Value, path)
+			}
+		}
+
+		expr := mappingValue(m, "expr")
+		if expr == nil {
+			v.add(m, "missing expression in %s", path)
+		} else if expr.Kind == yaml.ScalarNode {
+			if _, err := compute.Parse(expr.Value); err != nil {
+				v.add(expr, "invalid computed metric in %s: %v", path, err)
+			}
+		}
+	}
+}
+
+// checkRules reports the rules without a name or with a duplicate one, the
+// invalid conditions and the references to projects that are not configured.
+func (v *validator) checkRules(rules *yaml.Node, projects *yaml.Node) {
+	if rules == nil || rules.Kind != yaml.SequenceNode {
+		return
+	}
+
+	known := v.knownRefs(projects)
+	names := map[string]int{}
+
+	for i, r := range rules.Content {
+		if r.Kind != yaml.MappingNode {
+			continue
+		}
+
+		path := fmt.Sprintf("rules[%d]", i)
+
+		if name := mappingValue(r, "name"); name != nil && scalarValue(name) != "" {
+			if line, ok := names[name.Value]; ok {
+				v.add(name, "duplicate rule name %q in %s, already defined at line %d", name.Value, path, line)
+			} else {
+				names[name.Value] = name.Line
+			}
+		} else {
+			v.add(r, "missing rule name in %s", path)
+		}
+
+		warn, fail := mappingValue(r, "warn"), mappingValue(r, "fail")
+		if warn == nil && fail == nil {
+			v.add(r, "missing warn or fail condition in %s", path)
+		}
+
+		for _, c := range []*yaml.Node{warn, fail} {
+			if c == nil || c.Kind != yaml.ScalarNode {
+				continue
+			}
+
+			if _, err := rule.ParseCondition(c.Value); err != nil {
+				v.add(c, "invalid rule in %s: %v", path, err)
+			}
+		}
+
+		v.checkRefs(mappingValue(r, "projects"), known, path)
+	}
+}
+
+// checkScore reports the score metrics without a key or with a duplicate one,
+// and the weights and targets out of range.
+func (v *validator) checkScore(metrics *yaml.Node) {
+	if metrics == nil || metrics.Kind != yaml.SequenceNode {
+		return
+	}
+
+	keys := map[string]int{}
+
+	for i, m := range metrics.Content {
+		if m.Kind != yaml.MappingNode {
+			continue
+		}
+
+		path := fmt.Sprintf("score[%d]", i)
+
+		if key := mappingValue(m, "key"); key != nil && scalarValue(key) != "" {
+			if line, ok := keys[key.Value]; ok {
+				v.add(key, "duplicate score metric key %q in %s, already defined at line %d", key.Value, path, line)
+			} else {
+				keys[key.Value] = key.Line
+			}
+		} else {
+			v.add(m, "missing score metric key in %s", path)
+		}
+
+		if weight := mappingValue(m, "weight"); weight != nil {
+			if w, err := strconv.ParseFloat(scalarValue(weight), 64); err == nil && w <= 0 {
+				v.add(weight, "invalid weight %s in %s: it must be positive", weight.Value, path)
+			}
+		}
+
+		if target := mappingValue(m, "target"); target != nil {
+			if t, err := strconv.ParseFloat(scalarValue(target), 64); err == nil && t < 0 {
+				v.add(target, "invalid target %s in %s: it must not be negative", target.Value, path)
+			}
+		}
+	}
+}
+
+// knownRefs returns the names and IDs of the known and configured projects.
+func (v *validator) knownRefs(projects *yaml.Node) map[string]bool {
+	known := map[string]bool{}
+	for _, p := range v.known {
+		known[strconv.Itoa(p.ID)] = true
+		if p.Name != "" {
+			known[p.Name] = true
+		}
+	}
+
+	if projects != nil && projects.Kind == yaml.SequenceNode {
+		for _, p := range projects.Content {
+			known[scalarValue(mappingValue(p, "id"))] = true
+			known[scalarValue(mappingValue(p, "name"))] = true
+		}
+	}
+
+	return known
+}
+
+// checkRefs reports the references to projects that are not configured.
+func (v *validator) checkRefs(refs *yaml.Node, known map[string]bool, path string) {
+	if refs == nil || refs.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for _, ref := range refs.Content {
+		if ref.Value == "" || !known[ref.Value] {
+			v.add(ref, "unknown project %q in %s", ref.Value, path)
+		}
+	}
+}
+
+func checkRepoURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("the scheme must be http or https")
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("missing host")
+	}
+
+	return nil
+}
+
+// Keys returns the keys of a struct in the configuration file, and the types
+// of their values. The keys are the mapstructure tags, or the lowercase names
+// of the fields without them, as matched by viper.
+func Keys(t reflect.Type) map[string]reflect.Type {
+	keys := map[string]reflect.Type{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		keys[name] = field.Type
+	}
+
+	return keys
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
+
+func join(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func describe(path string) string {
+	if path == "" {
+		return "the configuration file"
+	}
+
+	return path
+}
@@ -0,0 +1,255 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/config"
+)
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		content  string
+		expected []config.Issue
+	}{
+		{
+			name:     "empty",
+			content:  "",
+			expected: []config.Issue{},
+		},
+		{
+			name: "valid",
+			content: `projects:
+  - id: 2296
+    name: testcontainers-go
+    repo_url:
+      - https://github.com/testcontainers/testcontainers-go
+    from: "2024-01-01"
+    to: last-month
+    thresholds:
+      open_issues_performance_overview: 100
+    labels:
+      lang: go
+groups:
+  - name: testcontainers
+    projects: [testcontainers-go, 2296]
+`,
+			expected: []config.Issue{},
+		},
+		{
+			name: "unknown-keys",
+			content: `projects:
+  - id: 2296
+    repo_urls:
+      - https://github.com/testcontainers/testcontainers-go
+projets: []
+`,
+			expected: []config.Issue{
+				{Line: 3, Message: `unknown key "repo_urls" in projects[0]`},
+				{Line: 5, Message: `unknown key "projets" in the configuration file`},
+			},
+		},
+		{
+			name: "types",
+			content: `projects:
+  - id: abc
+    tabs: overview
+    from: 2024-01-01
+    thresholds:
+      open_issues_performance_overview: many
+`,
+			expected: []config.Issue{
+				{Line: 2, Message: "projects[0].id must be an integer"},
+				{Line: 3, Message: "projects[0].tabs must be a list"},
+				{Line: 6, Message: "projects[0].thresholds.open_issues_performance_overview must be a number"},
+			},
+		},
+		{
+			name: "duplicates",
+			content: `projects:
+  - id: 2296
+    name: testcontainers-go
+  - id: 2296
+    name: testcontainers-go
+  - name: testcontainers-java
+`,
+			expected: []config.Issue{
+				{Line: 4, Message: "duplicate project ID 2296 in projects[1], already defined at line 2"},
+				{Line: 5, Message: `duplicate project name "testcontainers-go" in projects[1], already defined at line 3`},
+				{Line: 6, Message: "missing project ID in projects[2]"},
+			},
+		},
+		{
+			name: "repo-urls",
+			content: `projects:
+  - id: 2296
+    repo_url:
+      - github.com/testcontainers/testcontainers-go
+      - https://
+`,
+			expected: []config.Issue{
+				{Line: 4, Message: `malformed repository URL "github.com/testcontainers/testcontainers-go" in projects[0]: the scheme must be http or https`},
+				{Line: 5, Message: `malformed repository URL "https://" in projects[0]: missing host`},
+			},
+		},
+		{
+			name: "dates",
+			content: `projects:
+  - id: 2296
+    from: "2025-01-01"
+  - id: 7264
+    to: yesterweek
+`,
+			expected: []config.Issue{
+				{Line: 3, Message: "invalid period in projects[0]: from date 2025-01-01 is in the future"},
+				{Line: 5, Message: `invalid period in projects[1]: invalid to date "yesterweek": unknown date expression`},
+			},
+		},
+		{
+			name: "groups",
+			content: `projects:
+  - id: 2296
+    name: testcontainers-go
+groups:
+  - name: testcontainers
+    projects: [testcontainers-go, testcontainers-java]
+`,
+			expected: []config.Issue{
+				{Line: 6, Message: `unknown project "testcontainers-java" in groups[0]`},
+			},
+		},
+		{
+			name: "computed",
+			content: `computed:
+  - key: issue_closure_ratio
+    expr: issues_closed_activity_overview / issues_created_activity_overview
+  - key: issue_closure_ratio
+    expr: issues_closed_activity_overview /
+  - key: Reviews per person
+    expr: reviews_activity_overview / active_people_patches_community_overview
+  - name: missing
+`,
+			expected: []config.Issue{
+				{Line: 4, Message: `duplicate computed metric key "issue_closure_ratio" in computed[1], already defined at line 2`},
+				{Line: 5, Message: `invalid computed metric in computed[1]: invalid expression "issues_closed_activity_overview /": unexpected end`},
+				{Line: 6, Message: `invalid computed metric key "Reviews per person" in computed[2]: it must be lowercase letters, digits and underscores`},
+				{Line: 8, Message: "missing computed metric key in computed[3]"},
+				{Line: 8, Message: "missing expression in computed[3]"},
+			},
+		},
+		{
+			name: "rules",
+			content: `projects:
+  - id: 2296
+    name: testcontainers-go
+rules:
+  - name: slow-issues
+    warn: issues_median_time_to_close_overview > 20
+    fail: issues_median_time_to_close_overview >> 30
+    projects: [testcontainers-go, testcontainers-java]
+  - name: slow-issues
+  - fail: commits_overview < 10
+`,
+			expected: []config.Issue{
+				{Line: 7, Message: `invalid rule in rules[0]: invalid condition "issues_median_time_to_close_overview >> 30": expected <metric> <operator> <threshold>, e.g. commits_overview < 10`},
+				{Line: 8, Message: `unknown project "testcontainers-java" in rules[0]`},
+				{Line: 9, Message: `duplicate rule name "slow-issues" in rules[1], already defined at line 5`},
+				{Line: 9, Message: "missing warn or fail condition in rules[1]"},
+				{Line: 10, Message: "missing rule name in rules[2]"},
+			},
+		},
+		{
+			name: "goals",
+			content: `projects:
+  - id: 2296
+    goals:
+      - metric: onboardings_git_community_overview
+        target: 10
+        from: this-quarter
+      - name: faster reviews
+        from: 2024-04-01
+      - metric: commits_overview
+        target: 100
+      - metric: commits_overview
+        target: 100
+        from: 2024-01-01
+        deadline: 2024-12-31
+`,
+			expected: []config.Issue{
+				{Line: 7, Message: "missing goal metric in projects[0].goals[1]"},
+				{Line: 7, Message: "missing goal target in projects[0].goals[1]"},
+				{Line: 8, Message: "invalid goal period in projects[0].goals[1]: missing deadline: it's only optional for named ranges, e.g. this-quarter"},
+				{Line: 9, Message: "invalid goal period in projects[0].goals[2]: missing from date"},
+			},
+		},
+		{
+			name: "score",
+			content: `score:
+  - key: issue_closure_ratio_derived
+    weight: 0
+  - key: issue_closure_ratio_derived
+    target: -1
+  - weight: 2
+`,
+			expected: []config.Issue{
+				{Line: 3, Message: "invalid weight 0 in score[0]: it must be positive"},
+				{Line: 4, Message: `duplicate score metric key "issue_closure_ratio_derived" in score[1], already defined at line 2`},
+				{Line: 5, Message: "invalid target -1 in score[1]: it must not be negative"},
+				{Line: 6, Message: "missing score metric key in score[2]"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			issues, err := config.Validate([]byte(testCase.content), now, nil)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if len(issues) != len(testCase.expected) {
+				tt.Fatalf("expected %d issues, got %d: %v", len(testCase.expected), len(issues), issues)
+			}
+
+			for i, issue := range issues {
+				if issue != testCase.expected[i] {
+					tt.Errorf("expected %v, got %v", testCase.expected[i], issue)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateInvalidYAML(t *testing.T) {
+	_, err := config.Validate([]byte("projects: [\n"), time.Now(), nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestValidateInterpolated(t *testing.T) {
+	t.Setenv("GO_ID", "2296")
+	t.Setenv("GO_NAME", "go #1")
+	t.Setenv("GO_FROM", "someday")
+
+	content := `projects:
+  - id: ${GO_ID}
+    name: ${GO_NAME}
+    from: ${GO_FROM}
+`
+	issues, err := config.Validate([]byte(content), time.Now(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the lines are the ones of the file, before replacing the variables
+	if len(issues) != 1 || issues[0].Line != 4 {
+		t.Errorf("expected an issue in line 4, got %v", issues)
+	}
+}
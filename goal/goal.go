@@ -0,0 +1,153 @@
+package goal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/period"
+)
+
+const (
+	// Achieved is the status of a goal whose target is met for good: at the
+	// deadline, or before it for the cumulative metrics
+	Achieved = "achieved"
+	// OnTrack is the status of a goal whose target is met by the projected value
+	OnTrack = "on track"
+	// AtRisk is the status of a goal whose target is not met by the projected value
+	AtRisk = "at risk"
+	// Missed is the status of a goal whose target was not met at the deadline
+	Missed = "missed"
+	// NotStarted is the status of a goal whose period starts in the future
+	NotStarted = "not started"
+	// Unknown is the status of a goal whose metric was not reported
+	Unknown = "unknown"
+)
+
+// Goal is a target value of a metric of a project to reach by a deadline.
+type Goal struct {
+	// Name is the name of the goal. Default is the name of the metric.
+	Name string `json:"name,omitempty" mapstructure:"name" yaml:"name,omitempty"`
+	// Metric is the JSON key of the metric, including the derived and
+	// computed ones.
+	Metric string  `json:"metric" mapstructure:"metric" yaml:"metric"`
+	Target float64 `json:"target" mapstructure:"target" yaml:"target"`
+	// From and Deadline are the period of the goal, accepting the same
+	// expressions as the flags, although it may end in the future. The
+	// deadline defaults to the end of a named range, e.g. this-quarter.
+	From     string `json:"from" mapstructure:"from" yaml:"from"`
+	Deadline string `json:"deadline,omitempty" mapstructure:"deadline" yaml:"deadline,omitempty"`
+	// Cumulative tells if the metric accumulates over the period, e.g. the
+	// onboardings, so its value is projected to the deadline by the elapsed
+	// time. Default is true for the counts better when higher.
+	Cumulative *bool `json:"cumulative,omitempty" mapstructure:"cumulative" yaml:"cumulative,omitempty"`
+}
+
+// Validate checks that the goal has a metric and a valid period.
+func (g Goal) Validate(now time.Time) error {
+	if g.Metric == "" {
+		return fmt.Errorf("the goal has no metric")
+	}
+
+	if _, err := g.Window(now); err != nil {
+		return fmt.Errorf("goal %q: %w", g.Label(), err)
+	}
+
+	return nil
+}
+
+// Label returns the name of the goal, or its metric if it has no name.
+func (g Goal) Label() string {
+	if g.Name == "" {
+		return g.Metric
+	}
+
+	return g.Name
+}
+
+// Window returns the period of the goal.
+func (g Goal) Window(now time.Time) (period.Period, error) {
+	return period.ParseWindow(g.From, g.Deadline, now)
+}
+
+// Elapsed returns the fraction of the days of the period elapsed by now,
+// today included, between 0 and 1.
+func Elapsed(window period.Period, now time.Time) float64 {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, window.From.Location())
+
+	total := days(window.From, window.To)
+	elapsed := days(window.From, today)
+
+	switch {
+	case elapsed <= 0:
+		return 0
+	case elapsed >= total:
+		return 1
+	default:
+		return float64(elapsed) / float64(total)
+	}
+}
+
+// days returns the number of days from one date to another, both included.
+func days(from time.Time, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours()/24)) + 1
+}
+
+// Met returns true if the value reaches the target: at least the target for
+// the metrics better when higher, and at most for the ones better when lower.
+func Met(v float64, target float64, lowerIsBetter bool) bool {
+	if lowerIsBetter {
+		return v <= target
+	}
+
+	return v >= target
+}
+
+// Progress returns the percentage of the target reached by the value: the
+// ratio to the target for the metrics better when higher, and the inverse
+// ratio for the ones better when lower. It may exceed 100.
+func Progress(v float64, target float64, lowerIsBetter bool) float64 {
+	if lowerIsBetter {
+		if v == 0 {
+			return 100
+		}
+
+		return target / v * 100
+	}
+
+	if target == 0 {
+		return 100
+	}
+
+	return v / target * 100
+}
+
+// Projected returns the value expected at the deadline: the current one
+// extrapolated by the elapsed fraction for the cumulative metrics, and the
+// current one otherwise.
+func Projected(v float64, elapsed float64, cumulative bool) float64 {
+	if !cumulative || elapsed == 0 {
+		return v
+	}
+
+	return v / elapsed
+}
+
+// Status returns the status of a started goal from its current and projected
+// values.
+func Status(current float64, projected float64, target float64, elapsed float64, lowerIsBetter bool, cumulative bool) string {
+	met := Met(current, target, lowerIsBetter)
+
+	switch {
+	case elapsed >= 1 && met:
+		return Achieved
+	case elapsed >= 1:
+		return Missed
+	case met && cumulative:
+		return Achieved
+	case Met(projected, target, lowerIsBetter):
+		return OnTrack
+	default:
+		return AtRisk
+	}
+}
@@ -0,0 +1,103 @@
+package goal_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/goal"
+	"github.com/mdelapenya/cauldrongo/period"
+)
+
+func TestElapsed(t *testing.T) {
+	window := period.Period{
+		From: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		name     string
+		now      time.Time
+		expected float64
+	}{
+		{name: "before", now: time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC), expected: 0},
+		{name: "first day", now: time.Date(2024, time.April, 1, 12, 0, 0, 0, time.UTC), expected: 1.0 / 30},
+		{name: "middle", now: time.Date(2024, time.April, 15, 12, 0, 0, 0, time.UTC), expected: 0.5},
+		{name: "deadline", now: time.Date(2024, time.April, 30, 12, 0, 0, 0, time.UTC), expected: 1},
+		{name: "after", now: time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC), expected: 1},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			if got := goal.Elapsed(window, testCase.now); math.Abs(got-testCase.expected) > 1e-9 {
+				tt.Fatalf("expected %v but got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	testCases := []struct {
+		name          string
+		current       float64
+		target        float64
+		elapsed       float64
+		lowerIsBetter bool
+		cumulative    bool
+		expected      string
+	}{
+		{name: "cumulative met early", current: 12, target: 10, elapsed: 0.5, cumulative: true, expected: goal.Achieved},
+		{name: "cumulative on track", current: 6, target: 10, elapsed: 0.5, cumulative: true, expected: goal.OnTrack},
+		{name: "cumulative at risk", current: 4, target: 10, elapsed: 0.5, cumulative: true, expected: goal.AtRisk},
+		{name: "time met before the deadline", current: 1.5, target: 2, elapsed: 0.5, lowerIsBetter: true, expected: goal.OnTrack},
+		{name: "time at risk", current: 3, target: 2, elapsed: 0.5, lowerIsBetter: true, expected: goal.AtRisk},
+		{name: "time achieved", current: 1.5, target: 2, elapsed: 1, lowerIsBetter: true, expected: goal.Achieved},
+		{name: "missed", current: 8, target: 10, elapsed: 1, cumulative: true, expected: goal.Missed},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			projected := goal.Projected(testCase.current, testCase.elapsed, testCase.cumulative)
+			got := goal.Status(testCase.current, projected, testCase.target, testCase.elapsed, testCase.lowerIsBetter, testCase.cumulative)
+			if got != testCase.expected {
+				tt.Fatalf("expected %s but got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestProgress(t *testing.T) {
+	if got := goal.Progress(4, 10, false); got != 40 {
+		t.Fatalf("expected 40 but got %v", got)
+	}
+
+	if got := goal.Progress(4, 2, true); got != 50 {
+		t.Fatalf("expected 50 but got %v", got)
+	}
+
+	if got := goal.Progress(0, 2, true); got != 100 {
+		t.Fatalf("expected 100 but got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC)
+
+	if err := (goal.Goal{Metric: "commits_overview", Target: 10, From: "this-quarter"}).Validate(now); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (goal.Goal{Target: 10, From: "this-quarter"}).Validate(now); err == nil {
+		t.Fatal("expected an error for a goal without metric")
+	}
+
+	if err := (goal.Goal{Metric: "commits_overview", Target: 10, From: "2024-04-01"}).Validate(now); err == nil {
+		t.Fatal("expected an error for a goal without deadline")
+	}
+}
@@ -0,0 +1,113 @@
+package period
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Interval is the length of the windows a period is split into.
+type Interval string
+
+const (
+	Week    Interval = "week"
+	Month   Interval = "month"
+	Quarter Interval = "quarter"
+	Year    Interval = "year"
+)
+
+// ParseInterval validates the name of an interval.
+func ParseInterval(s string) (Interval, error) {
+	i := Interval(strings.ToLower(s))
+	switch i {
+	case Week, Month, Quarter, Year:
+		return i, nil
+	}
+
+	return "", fmt.Errorf("invalid interval %q: possible values are week, month, quarter and year", s)
+}
+
+// start returns the first day of the calendar interval containing t. Weeks
+// start on Monday.
+func (i Interval) start(t time.Time) time.Time {
+	switch i {
+	case Week:
+		offset := (int(t.Weekday()) + 6) % 7
+		return truncate(t).AddDate(0, 0, -offset)
+	case Quarter:
+		return quarterStart(t)
+	case Year:
+		return yearStart(t)
+	default:
+		return monthStart(t)
+	}
+}
+
+func (i Interval) next(t time.Time) time.Time {
+	switch i {
+	case Week:
+		return t.AddDate(0, 0, 7)
+	case Quarter:
+		return t.AddDate(0, 3, 0)
+	case Year:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 1, 0)
+	}
+}
+
+// Label names the calendar interval a window belongs to, e.g. 2024-W03,
+// 2024-01, 2024-Q1 or 2024.
+func (i Interval) Label(p Period) string {
+	switch i {
+	case Week:
+		year, week := p.From.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case Quarter:
+		return fmt.Sprintf("%d-Q%d", p.From.Year(), (int(p.From.Month())-1)/3+1)
+	case Year:
+		return fmt.Sprintf("%d", p.From.Year())
+	default:
+		return p.From.Format("2006-01")
+	}
+}
+
+// Split divides the period into consecutive windows aligned to the calendar
+// intervals, so the first and last windows may be shorter than the interval.
+func (p Period) Split(i Interval) []Period {
+	windows := []Period{}
+
+	for start := p.From; !start.After(p.To); {
+		next := i.next(i.start(start))
+
+		end := next.AddDate(0, 0, -1)
+		if end.After(p.To) {
+			end = p.To
+		}
+
+		windows = append(windows, Period{From: start, To: end})
+		start = next
+	}
+
+	return windows
+}
+
+// SplitComplete divides the period as Split does, dropping the first and last
+// windows if they are shorter than the interval, so the values of all the
+// windows are comparable.
+func (p Period) SplitComplete(i Interval) []Period {
+	complete := []Period{}
+	for _, w := range p.Split(i) {
+		if i.Complete(w) {
+			complete = append(complete, w)
+		}
+	}
+
+	return complete
+}
+
+// Complete returns true if the window spans a whole calendar interval.
+func (i Interval) Complete(w Period) bool {
+	start := i.start(w.From)
+	return start.Equal(truncate(w.From)) && truncate(w.To).Equal(i.next(start).AddDate(0, 0, -1))
+}
@@ -0,0 +1,234 @@
+package period
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Layout is the format of the dates used by Cauldron, YYYY-MM-DD
+	Layout = "2006-01-02"
+
+	// DefaultFrom is the default start of a period: one year ago
+	DefaultFrom = "1y"
+)
+
+// Period is a range of days, both ends included.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+func (p Period) String() string {
+	return p.From.Format(Layout) + ".." + p.To.Format(Layout)
+}
+
+// Previous returns the period of the same number of days right before.
+func (p Period) Previous() Period {
+	days := int(p.To.Sub(p.From).Hours()/24+0.5) + 1
+	to := p.From.AddDate(0, 0, -1)
+
+	return Period{From: to.AddDate(0, 0, 1-days), To: to}
+}
+
+// Parse converts the from and to date expressions into a validated period,
+// relative to now. An empty from defaults to one year ago, and an empty to
+// defaults to the end of the from expression if it is a named range, e.g.
+// "last-quarter", or to today otherwise.
+//
+// Supported expressions are:
+//   - absolute dates: 2024-04-01
+//   - relative dates: 30d, 12w, 6m or 2y ago
+//   - named dates: today, yesterday
+//   - named ranges: ytd, this-year, last-year, this-quarter, last-quarter,
+//     this-month, last-month
+func Parse(from string, to string, now time.Time) (Period, error) {
+	today := truncate(now)
+
+	if from == "" {
+		from = DefaultFrom
+	}
+
+	fromStart, fromEnd, err := parse(from, today)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid from date %q: %w", from, err)
+	}
+
+	p := Period{From: fromStart, To: today}
+
+	endsInRange := isRange(from)
+	if endsInRange {
+		p.To = fromEnd
+	}
+
+	if to != "" {
+		_, toEnd, err := parse(to, today)
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid to date %q: %w", to, err)
+		}
+
+		p.To = toEnd
+		endsInRange = isRange(to)
+	}
+
+	// named ranges may end in the future, e.g. this-year, so they are cut at today
+	if endsInRange && p.To.After(today) {
+		p.To = today
+	}
+
+	if p.From.After(today) {
+		return Period{}, fmt.Errorf("from date %s is in the future", p.From.Format(Layout))
+	}
+
+	if p.To.After(today) {
+		return Period{}, fmt.Errorf("to date %s is in the future", p.To.Format(Layout))
+	}
+
+	if p.From.After(p.To) {
+		return Period{}, fmt.Errorf("from date %s is after to date %s", p.From.Format(Layout), p.To.Format(Layout))
+	}
+
+	return p, nil
+}
+
+// ParseWindow converts the from and deadline date expressions of a goal into
+// a period relative to now, which may end in the future. An empty deadline
+// defaults to the end of the from expression if it is a named range, e.g.
+// "this-quarter", and it's required otherwise.
+func ParseWindow(from string, deadline string, now time.Time) (Period, error) {
+	today := truncate(now)
+
+	if from == "" {
+		return Period{}, fmt.Errorf("missing from date")
+	}
+
+	fromStart, fromEnd, err := parse(from, today)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid from date %q: %w", from, err)
+	}
+
+	p := Period{From: fromStart, To: fromEnd}
+
+	if deadline != "" {
+		if _, p.To, err = parse(deadline, today); err != nil {
+			return Period{}, fmt.Errorf("invalid deadline %q: %w", deadline, err)
+		}
+	} else if !isRange(from) {
+		return Period{}, fmt.Errorf("missing deadline: it's only optional for named ranges, e.g. this-quarter")
+	}
+
+	if p.From.After(p.To) {
+		return Period{}, fmt.Errorf("from date %s is after deadline %s", p.From.Format(Layout), p.To.Format(Layout))
+	}
+
+	return p, nil
+}
+
+// ranges are the named ranges, returning their first and last days.
+var ranges = map[string]func(today time.Time) (time.Time, time.Time){
+	"ytd": func(today time.Time) (time.Time, time.Time) {
+		return yearStart(today), today
+	},
+	"this-year": func(today time.Time) (time.Time, time.Time) {
+		start := yearStart(today)
+		return start, start.AddDate(1, 0, -1)
+	},
+	"last-year": func(today time.Time) (time.Time, time.Time) {
+		start := yearStart(today).AddDate(-1, 0, 0)
+		return start, start.AddDate(1, 0, -1)
+	},
+	"this-quarter": func(today time.Time) (time.Time, time.Time) {
+		start := quarterStart(today)
+		return start, start.AddDate(0, 3, -1)
+	},
+	"last-quarter": func(today time.Time) (time.Time, time.Time) {
+		start := quarterStart(today).AddDate(0, -3, 0)
+		return start, start.AddDate(0, 3, -1)
+	},
+	"this-month": func(today time.Time) (time.Time, time.Time) {
+		start := monthStart(today)
+		return start, start.AddDate(0, 1, -1)
+	},
+	"last-month": func(today time.Time) (time.Time, time.Time) {
+		start := monthStart(today).AddDate(0, -1, 0)
+		return start, start.AddDate(0, 1, -1)
+	},
+}
+
+func isRange(expr string) bool {
+	_, ok := ranges[strings.ToLower(strings.TrimSpace(expr))]
+	return ok
+}
+
+// IsRange returns true if the expression is a named range, e.g. last-quarter,
+// which ends the period when it's the from date.
+func IsRange(expr string) bool {
+	return isRange(expr)
+}
+
+// parse returns the first and last days of an expression, which are the
+// same day for the expressions that are not ranges.
+func parse(expr string, today time.Time) (time.Time, time.Time, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+
+	switch expr {
+	case "today":
+		return today, today, nil
+	case "yesterday":
+		d := today.AddDate(0, 0, -1)
+		return d, d, nil
+	}
+
+	if r, ok := ranges[expr]; ok {
+		start, end := r(today)
+		return start, end, nil
+	}
+
+	if d, err := time.ParseInLocation(Layout, expr, today.Location()); err == nil {
+		return d, d, nil
+	}
+
+	if len(expr) < 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("unknown date expression")
+	}
+
+	n, err := strconv.Atoi(expr[:len(expr)-1])
+	if err != nil || n < 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("unknown date expression")
+	}
+
+	var d time.Time
+	switch expr[len(expr)-1] {
+	case 'd':
+		d = today.AddDate(0, 0, -n)
+	case 'w':
+		d = today.AddDate(0, 0, -7*n)
+	case 'm':
+		d = today.AddDate(0, -n, 0)
+	case 'y':
+		d = today.AddDate(-n, 0, 0)
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unknown date expression")
+	}
+
+	return d, d, nil
+}
+
+func truncate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func yearStart(t time.Time) time.Time {
+	return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+}
+
+func quarterStart(t time.Time) time.Time {
+	month := time.Month((int(t.Month())-1)/3*3 + 1)
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,259 @@
+package period_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/period"
+)
+
+func TestParse(t *testing.T) {
+	// a Wednesday in the second quarter
+	now := time.Date(2024, time.May, 15, 13, 45, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{name: "defaults", from: "", to: "", expected: "2023-05-15..2024-05-15"},
+		{name: "absolute", from: "2024-04-01", to: "2024-04-16", expected: "2024-04-01..2024-04-16"},
+		{name: "days", from: "30d", to: "", expected: "2024-04-15..2024-05-15"},
+		{name: "weeks", from: "12w", to: "", expected: "2024-02-21..2024-05-15"},
+		{name: "months", from: "6m", to: "1m", expected: "2023-11-15..2024-04-15"},
+		{name: "ytd", from: "ytd", to: "", expected: "2024-01-01..2024-05-15"},
+		{name: "this-year", from: "this-year", to: "", expected: "2024-01-01..2024-05-15"},
+		{name: "last-quarter", from: "last-quarter", to: "", expected: "2024-01-01..2024-03-31"},
+		{name: "last-month", from: "last-month", to: "", expected: "2024-04-01..2024-04-30"},
+		{name: "range-to", from: "2023-06-01", to: "last-month", expected: "2023-06-01..2024-04-30"},
+		{name: "today", from: "today", to: "today", expected: "2024-05-15..2024-05-15"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			p, err := period.Parse(testCase.from, testCase.to, now)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if p.String() != testCase.expected {
+				tt.Fatalf("expected %s but got %s", testCase.expected, p.String())
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 13, 45, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{name: "unknown", from: "yesteryear", to: ""},
+		{name: "malformed", from: "2024-13-01", to: ""},
+		{name: "future-from", from: "2024-06-01", to: ""},
+		{name: "future-to", from: "2024-04-01", to: "2024-06-01"},
+		{name: "reversed", from: "2024-04-16", to: "2024-04-01"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			if _, err := period.Parse(testCase.from, testCase.to, now); err == nil {
+				tt.Fatalf("expected an error for from=%q to=%q", testCase.from, testCase.to)
+			}
+		})
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 13, 45, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		from     string
+		deadline string
+		expected string
+		err      bool
+	}{
+		{name: "absolute", from: "2024-04-01", deadline: "2024-06-30", expected: "2024-04-01..2024-06-30"},
+		{name: "named range", from: "this-quarter", deadline: "", expected: "2024-04-01..2024-06-30"},
+		{name: "future from", from: "2024-07-01", deadline: "2024-09-30", expected: "2024-07-01..2024-09-30"},
+		{name: "missing deadline", from: "2024-04-01", deadline: "", err: true},
+		{name: "missing from", from: "", deadline: "2024-06-30", err: true},
+		{name: "reversed", from: "2024-04-01", deadline: "2024-03-01", err: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			p, err := period.ParseWindow(testCase.from, testCase.deadline, now)
+			if testCase.err {
+				if err == nil {
+					tt.Fatalf("expected an error but got %s", p)
+				}
+				return
+			}
+
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if p.String() != testCase.expected {
+				tt.Fatalf("expected %s but got %s", testCase.expected, p.String())
+			}
+		})
+	}
+}
+
+func TestPrevious(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected string
+	}{
+		{name: "quarter", from: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), to: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC), expected: "2024-01-01..2024-03-31"},
+		{name: "day", from: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), to: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), expected: "2024-02-29..2024-02-29"},
+		{name: "30 days", from: time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC), to: time.Date(2024, time.May, 14, 0, 0, 0, 0, time.UTC), expected: "2024-03-16..2024-04-14"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			p := period.Period{From: testCase.from, To: testCase.to}
+			if got := p.Previous().String(); got != testCase.expected {
+				tt.Fatalf("expected %s but got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	p := period.Period{
+		From: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.April, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		interval period.Interval
+		expected []string
+		labels   []string
+	}{
+		{
+			interval: period.Month,
+			expected: []string{"2024-01-15..2024-01-31", "2024-02-01..2024-02-29", "2024-03-01..2024-03-31", "2024-04-01..2024-04-10"},
+			labels:   []string{"2024-01", "2024-02", "2024-03", "2024-04"},
+		},
+		{
+			interval: period.Quarter,
+			expected: []string{"2024-01-15..2024-03-31", "2024-04-01..2024-04-10"},
+			labels:   []string{"2024-Q1", "2024-Q2"},
+		},
+		{
+			interval: period.Year,
+			expected: []string{"2024-01-15..2024-04-10"},
+			labels:   []string{"2024"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(string(testCase.interval), func(tt *testing.T) {
+			tt.Parallel()
+
+			windows := p.Split(testCase.interval)
+			if len(windows) != len(testCase.expected) {
+				tt.Fatalf("expected %d windows but got %v", len(testCase.expected), windows)
+			}
+
+			for i, w := range windows {
+				if w.String() != testCase.expected[i] {
+					tt.Fatalf("expected window %s but got %s", testCase.expected[i], w.String())
+				}
+
+				if label := testCase.interval.Label(w); label != testCase.labels[i] {
+					tt.Fatalf("expected label %s but got %s", testCase.labels[i], label)
+				}
+			}
+		})
+	}
+
+	t.Run("week", func(tt *testing.T) {
+		tt.Parallel()
+
+		// from a Wednesday to the Tuesday two weeks later
+		weeks := period.Period{
+			From: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, time.May, 14, 0, 0, 0, 0, time.UTC),
+		}.Split(period.Week)
+
+		expected := []string{"2024-05-01..2024-05-05", "2024-05-06..2024-05-12", "2024-05-13..2024-05-14"}
+		if len(weeks) != len(expected) {
+			tt.Fatalf("expected %d windows but got %v", len(expected), weeks)
+		}
+
+		for i, w := range weeks {
+			if w.String() != expected[i] {
+				tt.Fatalf("expected window %s but got %s", expected[i], w.String())
+			}
+		}
+	})
+}
+
+func TestSplitComplete(t *testing.T) {
+	// the first month and the first quarter are partial, starting on the 15th,
+	// and so are the last ones, ending on the 10th
+	p := period.Period{
+		From: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.October, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		interval period.Interval
+		expected []string
+	}{
+		{
+			interval: period.Month,
+			expected: []string{"2024-02-01..2024-02-29", "2024-03-01..2024-03-31", "2024-04-01..2024-04-30", "2024-05-01..2024-05-31", "2024-06-01..2024-06-30", "2024-07-01..2024-07-31", "2024-08-01..2024-08-31", "2024-09-01..2024-09-30"},
+		},
+		{
+			interval: period.Quarter,
+			expected: []string{"2024-04-01..2024-06-30", "2024-07-01..2024-09-30"},
+		},
+		{
+			interval: period.Year,
+			expected: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(string(testCase.interval), func(tt *testing.T) {
+			tt.Parallel()
+
+			windows := p.SplitComplete(testCase.interval)
+			if len(windows) != len(testCase.expected) {
+				tt.Fatalf("expected %d windows but got %v", len(testCase.expected), windows)
+			}
+
+			for i, w := range windows {
+				if w.String() != testCase.expected[i] {
+					tt.Fatalf("expected window %s but got %s", testCase.expected[i], w.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package project
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Group is a named family of projects, whose metrics can be rolled up.
+type Group struct {
+	Name string `json:"name" yaml:"name"`
+	// Projects are the names or the IDs of the members of the group.
+	Projects []string `json:"projects" yaml:"projects"`
+}
+
+// Members resolves the projects of the group from the configured ones,
+// matching them by name or by ID.
+func (g Group) Members(projects []Project) ([]Project, error) {
+	members := make([]Project, 0, len(g.Projects))
+
+	for _, ref := range g.Projects {
+		found := false
+		for _, p := range projects {
+			if p.Name == ref || strconv.Itoa(p.ID) == ref {
+				members = append(members, p)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("group %s: project %s is not configured", g.Name, ref)
+		}
+	}
+
+	return members, nil
+}
+
+// FindGroup returns the group with the given name.
+func FindGroup(groups []Group, name string) (Group, error) {
+	for _, g := range groups {
+		if g.Name == name {
+			return g, nil
+		}
+	}
+
+	return Group{}, fmt.Errorf("group %s is not configured", name)
+}
@@ -1,7 +1,32 @@
 package project
 
+import "github.com/mdelapenya/cauldrongo/goal"
+
 type Project struct {
-	ID      int      `json:"id"`
-	Name    string   `json:"name"`
-	RepoURL []string `mapstructure:"repo_url"`
+	ID      int      `json:"id" yaml:"id"`
+	Name    string   `json:"name" yaml:"name,omitempty"`
+	RepoURL []string `mapstructure:"repo_url" yaml:"repo_url,omitempty"`
+
+	// The optional settings below override the defaults of the flags for
+	// the project, although a flag explicitly set always takes precedence.
+
+	// From and To are the default period, accepting the same expressions as the flags.
+	From string `json:"from,omitempty" mapstructure:"from" yaml:"from,omitempty"`
+	To   string `json:"to,omitempty" mapstructure:"to" yaml:"to,omitempty"`
+	// Tabs are the tabs to fetch.
+	Tabs []string `json:"tabs,omitempty" mapstructure:"tabs" yaml:"tabs,omitempty"`
+	// Fields are the JSON keys of the metrics to print, in all the tabs.
+	Fields []string `json:"fields,omitempty" mapstructure:"fields" yaml:"fields,omitempty"`
+	// Thresholds are the limits of the metrics, keyed by their JSON key.
+	// Values worse than the limit, according to the direction of the
+	// metric, are flagged in the output.
+	Thresholds map[string]float64 `json:"thresholds,omitempty" mapstructure:"thresholds" yaml:"thresholds,omitempty"`
+	// Format is the output format.
+	Format string `json:"format,omitempty" mapstructure:"format" yaml:"format,omitempty"`
+	// Output is the path of the file the output is written to, instead of stdout.
+	Output string `json:"output,omitempty" mapstructure:"output" yaml:"output,omitempty"`
+	// Labels are free-form key/value pairs describing the project.
+	Labels map[string]string `json:"labels,omitempty" mapstructure:"labels" yaml:"labels,omitempty"`
+	// Goals are the target values of the metrics tracked by the goals command.
+	Goals []goal.Goal `json:"goals,omitempty" mapstructure:"goals" yaml:"goals,omitempty"`
 }
@@ -0,0 +1,130 @@
+package project
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Selector filters the configured projects. Projects are selected if they
+// match any of the IDs, any of the names and all of the labels; empty
+// criteria match every project.
+type Selector struct {
+	IDs []int
+	// Names are patterns, supporting the syntax of path.Match, e.g. testcontainers-*
+	Names  []string
+	Labels map[string]string
+}
+
+// ParseSelector builds a selector from the values of the selection flags:
+// the project IDs, the name=<pattern> or id=<id> expressions, and the
+// key=value labels.
+func ParseSelector(ids []int, selects []string, labels []string) (Selector, error) {
+	s := Selector{IDs: append([]int{}, ids...), Labels: map[string]string{}}
+
+	for _, expr := range selects {
+		key, value, ok := strings.Cut(expr, "=")
+		if !ok {
+			return Selector{}, fmt.Errorf("invalid selector %q: expected name=<pattern> or id=<id>", expr)
+		}
+
+		switch key {
+		case "name":
+			if _, err := path.Match(value, ""); err != nil {
+				return Selector{}, fmt.Errorf("invalid selector %q: %w", expr, err)
+			}
+
+			s.Names = append(s.Names, value)
+		case "id":
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return Selector{}, fmt.Errorf("invalid selector %q: %w", expr, err)
+			}
+
+			s.IDs = append(s.IDs, id)
+		default:
+			return Selector{}, fmt.Errorf("invalid selector %q: expected name=<pattern> or id=<id>", expr)
+		}
+	}
+
+	for _, expr := range labels {
+		key, value, ok := strings.Cut(expr, "=")
+		if !ok || key == "" {
+			return Selector{}, fmt.Errorf("invalid label %q: expected key=value", expr)
+		}
+
+		s.Labels[key] = value
+	}
+
+	return s, nil
+}
+
+// Filter returns the projects matching the selector, failing if any of its
+// IDs or names, or the selector as a whole, matches no project.
+func (s Selector) Filter(projects []Project) ([]Project, error) {
+	for _, id := range s.IDs {
+		if !anyProject(projects, func(p Project) bool { return p.ID == id }) {
+			return nil, fmt.Errorf("project ID %d doesn't match any configured project", id)
+		}
+	}
+
+	for _, name := range s.Names {
+		if !anyProject(projects, func(p Project) bool { return matchName(name, p.Name) }) {
+			return nil, fmt.Errorf("project name %q doesn't match any configured project", name)
+		}
+	}
+
+	selected := []Project{}
+	for _, p := range projects {
+		if s.matches(p) {
+			selected = append(selected, p)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no configured project matches the selection")
+	}
+
+	return selected, nil
+}
+
+func (s Selector) matches(p Project) bool {
+	if len(s.IDs) > 0 || len(s.Names) > 0 {
+		matched := false
+		for _, id := range s.IDs {
+			matched = matched || p.ID == id
+		}
+
+		for _, name := range s.Names {
+			matched = matched || matchName(name, p.Name)
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	for k, v := range s.Labels {
+		if p.Labels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchName(pattern string, name string) bool {
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
+
+func anyProject(projects []Project, f func(Project) bool) bool {
+	for _, p := range projects {
+		if f(p) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package project_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+var projects = []project.Project{
+	{ID: 2296, Name: "testcontainers-go", Labels: map[string]string{"lang": "go", "team": "a"}},
+	{ID: 7264, Name: "testcontainers-java", Labels: map[string]string{"lang": "java", "team": "a"}},
+	{ID: 7265, Name: "testcontainers-dotnet", Labels: map[string]string{"lang": "dotnet", "team": "b"}},
+}
+
+func TestSelectorFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ids      []int
+		selects  []string
+		labels   []string
+		expected []int
+	}{
+		{name: "all", expected: []int{2296, 7264, 7265}},
+		{name: "ids", ids: []int{2296, 7265}, expected: []int{2296, 7265}},
+		{name: "name", selects: []string{"name=testcontainers-java"}, expected: []int{7264}},
+		{name: "name-pattern", selects: []string{"name=testcontainers-*a"}, expected: []int{7264}},
+		{name: "id-selector", selects: []string{"id=7265"}, expected: []int{7265}},
+		{name: "label", labels: []string{"team=a"}, expected: []int{2296, 7264}},
+		{name: "labels", labels: []string{"team=a", "lang=go"}, expected: []int{2296}},
+		{name: "name-and-label", selects: []string{"name=testcontainers-*"}, labels: []string{"team=b"}, expected: []int{7265}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			selector, err := project.ParseSelector(testCase.ids, testCase.selects, testCase.labels)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			selected, err := selector.Filter(projects)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if len(selected) != len(testCase.expected) {
+				tt.Fatalf("expected %v but got %v", testCase.expected, selected)
+			}
+
+			for i, p := range selected {
+				if p.ID != testCase.expected[i] {
+					tt.Fatalf("expected %v but got %v", testCase.expected, selected)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectorErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ids     []int
+		selects []string
+		labels  []string
+	}{
+		{name: "unknown-id", ids: []int{1}},
+		{name: "unknown-name", selects: []string{"name=testcontainers-rust"}},
+		{name: "unknown-label", labels: []string{"lang=rust"}},
+		{name: "no-intersection", selects: []string{"name=testcontainers-go"}, labels: []string{"team=b"}},
+		{name: "malformed-selector", selects: []string{"testcontainers-go"}},
+		{name: "unknown-selector", selects: []string{"lang=go"}},
+		{name: "malformed-label", labels: []string{"go"}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			selector, err := project.ParseSelector(testCase.ids, testCase.selects, testCase.labels)
+			if err != nil {
+				return
+			}
+
+			if _, err := selector.Filter(projects); err == nil {
+				tt.Fatal("expected an error")
+			}
+		})
+	}
+}
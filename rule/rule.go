@@ -0,0 +1,220 @@
+package rule
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mdelapenya/cauldrongo/project"
+)
+
+const (
+	// Pass is the status of a rule whose conditions are not met
+	Pass = "pass"
+	// Warn is the status of a rule whose warn condition is met
+	Warn = "warn"
+	// Fail is the status of a rule whose fail condition is met
+	Fail = "fail"
+)
+
+// statuses sorts the statuses from the least to the most severe.
+var statuses = map[string]int{Pass: 0, Warn: 1, Fail: 2}
+
+// Worst returns the most severe of the statuses, or Pass if there are none.
+func Worst(ss ...string) string {
+	worst := Pass
+	for _, s := range ss {
+		if statuses[s] > statuses[worst] {
+			worst = s
+		}
+	}
+
+	return worst
+}
+
+// Rule checks a metric of the projects against thresholds.
+type Rule struct {
+	Name string `json:"name" yaml:"name"`
+	// Warn and Fail are the conditions degrading the status of a project, as
+	// <metric> <operator> <threshold>, e.g. issues_median_time_to_close_overview > 30.
+	Warn string `json:"warn,omitempty" yaml:"warn,omitempty"`
+	Fail string `json:"fail,omitempty" yaml:"fail,omitempty"`
+	// Projects are the names or the IDs of the checked projects. Default is
+	// all the projects.
+	Projects []string `json:"projects,omitempty" yaml:"projects,omitempty"`
+}
+
+// Condition compares a metric with a threshold.
+type Condition struct {
+	Key       string
+	Operator  string
+	Threshold float64
+}
+
+var conditionRegexp = regexp.MustCompile(`^\s*([A-Za-z0-9_]+)\s*(>=|<=|==|!=|>|<)\s*(\S+)\s*$`)
+
+// ParseCondition parses a condition, as <metric> <operator> <threshold>,
+// where the operator is one of >, >=, <, <=, == or !=.
+func ParseCondition(s string) (Condition, error) {
+	matches := conditionRegexp.FindStringSubmatch(s)
+	if matches == nil {
+		return Condition{}, fmt.Errorf("invalid condition %q: expected <metric> <operator> <threshold>, e.g. commits_overview < 10", s)
+	}
+
+	threshold, err := strconv.ParseFloat(matches[3], 64)
+	if err != nil {
+		return Condition{}, fmt.Errorf("invalid condition %q: the threshold %s is not a number", s, matches[3])
+	}
+
+	return Condition{Key: matches[1], Operator: matches[2], Threshold: threshold}, nil
+}
+
+// Met returns true if the value meets the condition.
+func (c Condition) Met(v float64) bool {
+	switch c.Operator {
+	case ">":
+		return v > c.Threshold
+	case ">=":
+		return v >= c.Threshold
+	case "<":
+		return v < c.Threshold
+	case "<=":
+		return v <= c.Threshold
+	case "==":
+		return v == c.Threshold
+	default:
+		return v != c.Threshold
+	}
+}
+
+// String renders the condition with a normalized spacing.
+func (c Condition) String() string {
+	return c.Key + " " + c.Operator + " " + strconv.FormatFloat(c.Threshold, 'f', -1, 64)
+}
+
+// Validate checks that the rule has a name and valid conditions.
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("the rule has no name")
+	}
+
+	if r.Warn == "" && r.Fail == "" {
+		return fmt.Errorf("rule %s: it must have a warn or a fail condition", r.Name)
+	}
+
+	for _, c := range []string{r.Warn, r.Fail} {
+		if c == "" {
+			continue
+		}
+
+		if _, err := ParseCondition(c); err != nil {
+			return fmt.Errorf("rule %s: %w", r.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Keys returns the JSON keys of the metrics of the valid conditions of the rule.
+func (r Rule) Keys() []string {
+	keys := []string{}
+	for _, s := range []string{r.Fail, r.Warn} {
+		if c, err := ParseCondition(s); err == nil {
+			keys = append(keys, c.Key)
+		}
+	}
+
+	return keys
+}
+
+// Applies returns true if the rule checks the project.
+func (r Rule) Applies(p project.Project) bool {
+	if len(r.Projects) == 0 {
+		return true
+	}
+
+	for _, ref := range r.Projects {
+		if ref == p.Name || ref == strconv.Itoa(p.ID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Result is the outcome of a rule for a project.
+type Result struct {
+	Status string
+	// Condition is the condition met, or the most severe one if none is.
+	Condition string
+	Key       string
+	// Value is the value of the metric, nil if it was not reported.
+	Value   *float64
+	Message string
+}
+
+// Evaluate checks the rule against the values of the metrics of a project,
+// keyed by their JSON key. The fail condition is checked first, and the rule
+// is a warning if none of its conditions can be checked, as their metrics were
+// not reported.
+func (r Rule) Evaluate(values map[string]*float64) (Result, error) {
+	checks := []struct {
+		condition string
+		status    string
+	}{{r.Fail, Fail}, {r.Warn, Warn}}
+
+	// the conditions on the metrics not reported are skipped
+	var checked, missing []Condition
+	for _, check := range checks {
+		if check.condition == "" {
+			continue
+		}
+
+		c, err := ParseCondition(check.condition)
+		if err != nil {
+			return Result{}, fmt.Errorf("rule %s: %w", r.Name, err)
+		}
+
+		v := values[c.Key]
+		if v == nil {
+			missing = append(missing, c)
+			continue
+		}
+
+		if c.Met(*v) {
+			return Result{Status: check.status, Condition: c.String(), Key: c.Key, Value: v, Message: notReported(missing)}, nil
+		}
+
+		checked = append(checked, c)
+	}
+
+	if len(checked) == 0 && len(missing) > 0 {
+		return Result{Status: Warn, Condition: missing[0].String(), Key: missing[0].Key, Message: notReported(missing)}, nil
+	}
+
+	result := Result{Status: Pass, Message: notReported(missing)}
+	if len(checked) > 0 {
+		result.Condition, result.Key, result.Value = checked[0].String(), checked[0].Key, values[checked[0].Key]
+	}
+
+	return result, nil
+}
+
+// notReported returns the message of the conditions on the metrics not
+// reported, or an empty string.
+func notReported(missing []Condition) string {
+	keys := []string{}
+	for _, c := range missing {
+		if !slices.Contains(keys, c.Key) {
+			keys = append(keys, c.Key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return ""
+	}
+
+	return strings.Join(keys, ", ") + " was not reported"
+}
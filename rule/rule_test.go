@@ -0,0 +1,179 @@
+package rule_test
+
+import (
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/project"
+	"github.com/mdelapenya/cauldrongo/rule"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestParseCondition(t *testing.T) {
+	testCases := []struct {
+		condition string
+		expected  rule.Condition
+		err       bool
+	}{
+		{condition: "issues_median_time_to_close_overview > 30", expected: rule.Condition{Key: "issues_median_time_to_close_overview", Operator: ">", Threshold: 30}},
+		{condition: "onboardings_git_community_overview<2", expected: rule.Condition{Key: "onboardings_git_community_overview", Operator: "<", Threshold: 2}},
+		{condition: "  commits_overview >= -1.5 ", expected: rule.Condition{Key: "commits_overview", Operator: ">=", Threshold: -1.5}},
+		{condition: "commits_overview != 0", expected: rule.Condition{Key: "commits_overview", Operator: "!=", Threshold: 0}},
+		{condition: "commits_overview > many", err: true},
+		{condition: "commits_overview => 1", err: true},
+		{condition: "> 1", err: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.condition, func(tt *testing.T) {
+			tt.Parallel()
+
+			got, err := rule.ParseCondition(testCase.condition)
+			if testCase.err {
+				if err == nil {
+					tt.Fatalf("expected an error but got %v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if got != testCase.expected {
+				tt.Fatalf("expected %v but got %v", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	r := rule.Rule{
+		Name: "slow-issues",
+		Warn: "issues_median_time_to_close_overview > 20",
+		Fail: "issues_median_time_to_close_overview > 30",
+	}
+
+	testCases := []struct {
+		name      string
+		values    map[string]*float64
+		status    string
+		condition string
+	}{
+		{name: "pass", values: map[string]*float64{"issues_median_time_to_close_overview": float(10)}, status: rule.Pass, condition: "issues_median_time_to_close_overview > 30"},
+		{name: "warn", values: map[string]*float64{"issues_median_time_to_close_overview": float(25)}, status: rule.Warn, condition: "issues_median_time_to_close_overview > 20"},
+		{name: "fail", values: map[string]*float64{"issues_median_time_to_close_overview": float(35)}, status: rule.Fail, condition: "issues_median_time_to_close_overview > 30"},
+		{name: "not-reported", values: map[string]*float64{"issues_median_time_to_close_overview": nil}, status: rule.Warn, condition: "issues_median_time_to_close_overview > 30"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			got, err := r.Evaluate(testCase.values)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if got.Status != testCase.status || got.Condition != testCase.condition {
+				tt.Fatalf("expected %s (%s) but got %s (%s)", testCase.status, testCase.condition, got.Status, got.Condition)
+			}
+		})
+	}
+}
+
+func TestEvaluateMissingMetric(t *testing.T) {
+	r := rule.Rule{
+		Name: "reviews",
+		Warn: "reviews_median_time_to_close_overview > 7",
+		Fail: "open_reviews_performance_overview > 100",
+	}
+
+	testCases := []struct {
+		name      string
+		values    map[string]*float64
+		status    string
+		condition string
+		message   string
+	}{
+		{name: "warn", values: map[string]*float64{"reviews_median_time_to_close_overview": float(10)}, status: rule.Warn, condition: "reviews_median_time_to_close_overview > 7", message: "open_reviews_performance_overview was not reported"},
+		{name: "pass", values: map[string]*float64{"reviews_median_time_to_close_overview": float(5)}, status: rule.Pass, condition: "reviews_median_time_to_close_overview > 7", message: "open_reviews_performance_overview was not reported"},
+		{name: "fail", values: map[string]*float64{"open_reviews_performance_overview": float(120)}, status: rule.Fail, condition: "open_reviews_performance_overview > 100"},
+		{name: "not-reported", values: map[string]*float64{}, status: rule.Warn, condition: "open_reviews_performance_overview > 100", message: "open_reviews_performance_overview, reviews_median_time_to_close_overview was not reported"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			got, err := r.Evaluate(testCase.values)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if got.Status != testCase.status || got.Condition != testCase.condition || got.Message != testCase.message {
+				tt.Fatalf("expected %s (%s) %q but got %s (%s) %q", testCase.status, testCase.condition, testCase.message, got.Status, got.Condition, got.Message)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (rule.Rule{Name: "few-onboardings", Fail: "onboardings_git_community_overview < 2"}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range []rule.Rule{
+		{Fail: "commits_overview < 10"},
+		{Name: "empty"},
+		{Name: "invalid", Warn: "commits_overview ~ 10"},
+	} {
+		if err := r.Validate(); err == nil {
+			t.Fatalf("expected an error for %+v", r)
+		}
+	}
+}
+
+func TestApplies(t *testing.T) {
+	p := project.Project{ID: 2296, Name: "testcontainers-go"}
+
+	if !(rule.Rule{}).Applies(p) {
+		t.Fatal("expected a rule without projects to apply to all of them")
+	}
+
+	if !(rule.Rule{Projects: []string{"2296"}}).Applies(p) || !(rule.Rule{Projects: []string{"testcontainers-go"}}).Applies(p) {
+		t.Fatal("expected the rule to apply to the project by ID and by name")
+	}
+
+	if (rule.Rule{Projects: []string{"testcontainers-java"}}).Applies(p) {
+		t.Fatal("expected the rule not to apply to other projects")
+	}
+}
+
+func TestKeys(t *testing.T) {
+	r := rule.Rule{Warn: "issue_closure_ratio < 0.8", Fail: "open_issues_performance_overview > 100"}
+
+	keys := r.Keys()
+	if len(keys) != 2 || keys[0] != "open_issues_performance_overview" || keys[1] != "issue_closure_ratio" {
+		t.Fatalf("expected the keys of the fail and warn conditions but got %v", keys)
+	}
+
+	if keys := (rule.Rule{Warn: "invalid"}).Keys(); len(keys) != 0 {
+		t.Fatalf("expected no keys but got %v", keys)
+	}
+}
+
+func TestWorst(t *testing.T) {
+	if got := rule.Worst(); got != rule.Pass {
+		t.Fatalf("expected pass but got %s", got)
+	}
+
+	if got := rule.Worst(rule.Warn, rule.Fail, rule.Pass); got != rule.Fail {
+		t.Fatalf("expected fail but got %s", got)
+	}
+}
@@ -0,0 +1,163 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document, or a subschema of it.
+type Schema map[string]any
+
+var marshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Generator generates JSON Schemas from Go types by reflection, defining the
+// structs once under $defs and referencing them. The definitions are named
+// after the package and the name of the types, e.g. compute.Metric, so the
+// types of different packages with the same name don't collide.
+type Generator struct {
+	// tag is the struct tag naming the properties, e.g. json or yaml.
+	tag   string
+	types map[reflect.Type]Schema
+	defs  Schema
+}
+
+// NewGenerator returns a generator naming the properties after the given
+// struct tag. Fields without the tag are named as encoding/json does, and
+// fields tagged "-" are skipped.
+func NewGenerator(tag string) *Generator {
+	return &Generator{tag: tag, types: map[reflect.Type]Schema{}, defs: Schema{}}
+}
+
+// Define sets the schema of a type instead of deriving it from its fields,
+// e.g. for interfaces or types with a custom marshalling. It's defined under
+// $defs with the given name, which should be qualified by the package too.
+func (g *Generator) Define(t reflect.Type, name string, s Schema) {
+	g.defs[name] = s
+	g.types[t] = Schema{"$ref": "#/$defs/" + name}
+}
+
+// Document returns the schema of the type as a document, with the given
+// title and description, and the definitions referenced by it.
+func (g *Generator) Document(t reflect.Type, title string, description string) Schema {
+	return g.Root(g.For(t), title, description)
+}
+
+// Root returns the given schema as a document, with the given title and
+// description, and the definitions generated so far.
+func (g *Generator) Root(s Schema, title string, description string) Schema {
+	root := Schema{
+		"$schema":     Draft,
+		"title":       title,
+		"description": description,
+	}
+
+	for k, v := range s {
+		root[k] = v
+	}
+
+	if len(g.defs) > 0 {
+		root["$defs"] = g.defs
+	}
+
+	return root
+}
+
+// For returns the schema of a type, referencing the definitions of the structs.
+func (g *Generator) For(t reflect.Type) Schema {
+	if s, ok := g.types[t]; ok {
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.For(t.Elem()))
+	case reflect.Struct:
+		// the package name and the type name, e.g. compute.Metric
+		name := t.String()
+		ref := Schema{"$ref": "#/$defs/" + name}
+		g.types[t] = ref
+		g.defs[name] = g.object(t)
+
+		return ref
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": g.For(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": g.For(t.Elem())}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	default:
+		// any value, e.g. an interface without a definition
+		return Schema{}
+	}
+}
+
+// object returns the schema of a struct, with its exported fields as
+// properties, and the ones not omitted when empty as required. The
+// properties of the embedded structs are promoted.
+func (g *Generator) object(t reflect.Type) Schema {
+	properties := Schema{}
+	required := []string{}
+	g.fields(t, properties, &required)
+
+	s := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+
+	// types with a custom marshalling may add properties, e.g. the unknown
+	// fields of a tab
+	if !t.Implements(marshaler) {
+		s["additionalProperties"] = false
+	}
+
+	return s
+}
+
+func (g *Generator) fields(t reflect.Type, properties Schema, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag, hasTag := field.Tag.Lookup(g.tag)
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			g.fields(field.Type, properties, required)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = g.For(field.Type)
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func nullable(s Schema) Schema {
+	if t, ok := s["type"].(string); ok && len(s) == 1 {
+		return Schema{"type": []string{t, "null"}}
+	}
+
+	return Schema{"anyOf": []Schema{s, {"type": "null"}}}
+}
@@ -0,0 +1,75 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/schema"
+)
+
+type inner struct {
+	Value *float64 `json:"value"`
+}
+
+type embedded struct {
+	Rank int `json:"rank,omitempty"`
+}
+
+type document struct {
+	embedded
+	Name     string            `json:"name"`
+	Count    *int              `json:"count"`
+	Tags     []string          `json:"tags,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Inner    *inner            `json:"inner"`
+	Skipped  string            `json:"-"`
+	Untagged bool
+	hidden   string
+}
+
+func TestGenerator(t *testing.T) {
+	g := schema.NewGenerator("json")
+	s := g.Root(g.For(reflect.TypeOf(document{})), "document", "A test document.")
+
+	bs, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"$defs":{` +
+		`"schema_test.document":{"additionalProperties":false,"properties":{` +
+		`"Untagged":{"type":"boolean"},` +
+		`"count":{"type":["integer","null"]},` +
+		`"inner":{"anyOf":[{"$ref":"#/$defs/schema_test.inner"},{"type":"null"}]},` +
+		`"labels":{"additionalProperties":{"type":"string"},"type":"object"},` +
+		`"name":{"type":"string"},` +
+		`"rank":{"type":"integer"},` +
+		`"tags":{"items":{"type":"string"},"type":"array"}},` +
+		`"required":["name","count","inner","Untagged"],"type":"object"},` +
+		`"schema_test.inner":{"additionalProperties":false,"properties":{"value":{"type":["number","null"]}},"required":["value"],"type":"object"}},` +
+		`"$ref":"#/$defs/schema_test.document",` +
+		`"$schema":"https://json-schema.org/draft/2020-12/schema",` +
+		`"description":"A test document.","title":"document"}`
+
+	if string(bs) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, string(bs))
+	}
+}
+
+func TestGeneratorDefine(t *testing.T) {
+	g := schema.NewGenerator("json")
+	g.Define(reflect.TypeOf(inner{}), "Inner", schema.Schema{"type": "object"})
+
+	s := g.For(reflect.TypeOf([]inner{}))
+
+	bs, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"items":{"$ref":"#/$defs/Inner"},"type":"array"}`
+	if string(bs) != expected {
+		t.Errorf("expected %s, got %s", expected, string(bs))
+	}
+}
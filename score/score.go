@@ -0,0 +1,78 @@
+package score
+
+import (
+	"fmt"
+	"math"
+)
+
+// Metric is a metric of the health score, with its weight and, optionally, a
+// fixed target.
+type Metric struct {
+	// Key is the JSON key of the metric, including the derived and computed ones.
+	Key string `json:"key" yaml:"key"`
+	// Weight is the relative importance of the metric. Default is 1.
+	Weight *float64 `json:"weight,omitempty" yaml:"weight,omitempty"`
+	// Target is the value reaching the full score. Default is normalizing the
+	// value between the worst and the best ones of the projects.
+	Target *float64 `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+// Validate checks that the metric has a key, a positive weight and a
+// non-negative target.
+func (m Metric) Validate() error {
+	if m.Key == "" {
+		return fmt.Errorf("the score metric has no key")
+	}
+
+	if m.Weight != nil && *m.Weight <= 0 {
+		return fmt.Errorf("score metric %s: the weight must be positive", m.Key)
+	}
+
+	if m.Target != nil && *m.Target < 0 {
+		return fmt.Errorf("score metric %s: the target must not be negative", m.Key)
+	}
+
+	return nil
+}
+
+// Weighting returns the weight of the metric.
+func (m Metric) Weighting() float64 {
+	if m.Weight == nil {
+		return 1
+	}
+
+	return *m.Weight
+}
+
+// AgainstTarget normalizes a value between 0 and 1 by how much it reaches the
+// target: the ratio to the target for the metrics better when higher, and the
+// inverse ratio for the ones better when lower, capped at 1.
+func AgainstTarget(v float64, target float64, lowerIsBetter bool) float64 {
+	if lowerIsBetter {
+		if v <= target {
+			return 1
+		}
+
+		return target / v
+	}
+
+	if target == 0 || v >= target {
+		return 1
+	}
+
+	return math.Max(v/target, 0)
+}
+
+// AgainstRange normalizes a value between 0 and 1 by its position between the
+// worst and the best values. All the values are the best if they are equal.
+func AgainstRange(v float64, min float64, max float64, lowerIsBetter bool) float64 {
+	if max == min {
+		return 1
+	}
+
+	if lowerIsBetter {
+		return (max - v) / (max - min)
+	}
+
+	return (v - min) / (max - min)
+}
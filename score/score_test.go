@@ -0,0 +1,67 @@
+package score_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mdelapenya/cauldrongo/score"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		metric score.Metric
+		err    bool
+	}{
+		{name: "defaults", metric: score.Metric{Key: "commits_overview"}},
+		{name: "weight and target", metric: score.Metric{Key: "commits_overview", Weight: float(0.5), Target: float(100)}},
+		{name: "no key", metric: score.Metric{}, err: true},
+		{name: "zero weight", metric: score.Metric{Key: "commits_overview", Weight: float(0)}, err: true},
+		{name: "negative target", metric: score.Metric{Key: "commits_overview", Target: float(-1)}, err: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			err := testCase.metric.Validate()
+			if testCase.err != (err != nil) {
+				tt.Fatalf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{name: "below target", got: score.AgainstTarget(25, 100, false), expected: 0.25},
+		{name: "over target", got: score.AgainstTarget(150, 100, false), expected: 1},
+		{name: "negative value", got: score.AgainstTarget(-5, 100, false), expected: 0},
+		{name: "zero target", got: score.AgainstTarget(0, 0, false), expected: 1},
+		{name: "over target lower is better", got: score.AgainstTarget(40, 10, true), expected: 0.25},
+		{name: "below target lower is better", got: score.AgainstTarget(5, 10, true), expected: 1},
+		{name: "range", got: score.AgainstRange(30, 10, 50, false), expected: 0.5},
+		{name: "range lower is better", got: score.AgainstRange(20, 10, 50, true), expected: 0.75},
+		{name: "equal range", got: score.AgainstRange(10, 10, 10, true), expected: 1},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			tt.Parallel()
+
+			if math.Abs(testCase.got-testCase.expected) > 1e-9 {
+				tt.Fatalf("expected %v but got %v", testCase.expected, testCase.got)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// pure Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// schemaVersion is the version of the database schema, kept in the
+// user_version pragma to migrate it.
+const schemaVersion = 1
+
+const createSchema = `
+CREATE TABLE IF NOT EXISTS snapshots (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	project_id   INTEGER NOT NULL,
+	project_name TEXT    NOT NULL,
+	tab          TEXT    NOT NULL,
+	period_from  TEXT    NOT NULL,
+	period_to    TEXT    NOT NULL,
+	repo_urls    TEXT    NOT NULL,
+	fetched_at   TEXT    NOT NULL,
+	response     TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS snapshots_project_tab ON snapshots (project_id, tab, fetched_at);
+`
+
+// DefaultPath returns the default path of the store:
+// $XDG_DATA_HOME/cauldrongo/history.db, where $XDG_DATA_HOME defaults to
+// ~/.local/share.
+func DefaultPath(home string, xdgDataHome string) string {
+	if xdgDataHome == "" {
+		xdgDataHome = filepath.Join(home, ".local", "share")
+	}
+
+	return filepath.Join(xdgDataHome, "cauldrongo", "history.db")
+}
+
+// Snapshot is the raw response of a tab of a project, fetched for a period.
+type Snapshot struct {
+	ID          int64
+	ProjectID   int
+	ProjectName string
+	Tab         string
+	// From and To are the dates of the period, as YYYY-MM-DD.
+	From     string
+	To       string
+	RepoURLs []string
+	// FetchedAt is the time the tab was fetched, in UTC.
+	FetchedAt time.Time
+	Response  json.RawMessage
+}
+
+// Query filters the snapshots. Empty criteria match every snapshot.
+type Query struct {
+	ProjectIDs []int
+	Tabs       []string
+	// Since and Until limit the time the snapshots were fetched, both included.
+	Since time.Time
+	Until time.Time
+}
+
+// Store keeps the snapshots in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the store at the given path, creating the database and its
+// directory if missing.
+func Open(path string) (*Store, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("error creating the store directory: %w", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the store: %w", err)
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("error reading the store version: %w", err)
+	}
+
+	if version > schemaVersion {
+		return fmt.Errorf("the store has version %d, newer than the supported one, %d", version, schemaVersion)
+	}
+
+	if _, err := db.Exec(createSchema); err != nil {
+		return fmt.Errorf("error creating the store: %w", err)
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+		return fmt.Errorf("error writing the store version: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the database of the store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save records the snapshots in a single transaction, setting their IDs.
+func (s *Store) Save(snapshots []*Snapshot) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error saving the snapshots: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO snapshots
+		(project_id, project_name, tab, period_from, period_to, repo_urls, fetched_at, response)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("error saving the snapshots: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, snapshot := range snapshots {
+		repoURLs := snapshot.RepoURLs
+		if repoURLs == nil {
+			repoURLs = []string{}
+		}
+
+		bs, err := json.Marshal(repoURLs)
+		if err != nil {
+			return fmt.Errorf("error saving the snapshots: %w", err)
+		}
+
+		res, err := stmt.Exec(
+			snapshot.ProjectID, snapshot.ProjectName, snapshot.Tab, snapshot.From, snapshot.To,
+			string(bs), snapshot.FetchedAt.UTC().Format(time.RFC3339), string(snapshot.Response),
+		)
+		if err != nil {
+			return fmt.Errorf("error saving the snapshots: %w", err)
+		}
+
+		if snapshot.ID, err = res.LastInsertId(); err != nil {
+			return fmt.Errorf("error saving the snapshots: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error saving the snapshots: %w", err)
+	}
+
+	return nil
+}
+
+// Snapshots returns the snapshots matching the query, from the oldest to the
+// newest.
+func (s *Store) Snapshots(q Query) ([]Snapshot, error) {
+	conditions := []string{}
+	args := []any{}
+
+	if len(q.ProjectIDs) > 0 {
+		conditions = append(conditions, "project_id IN ("+placeholders(len(q.ProjectIDs))+")")
+		for _, id := range q.ProjectIDs {
+			args = append(args, id)
+		}
+	}
+
+	if len(q.Tabs) > 0 {
+		conditions = append(conditions, "tab IN ("+placeholders(len(q.Tabs))+")")
+		for _, tab := range q.Tabs {
+			args = append(args, tab)
+		}
+	}
+
+	// the times are stored in UTC as RFC 3339, so they sort as strings
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "fetched_at >= ?")
+		args = append(args, q.Since.UTC().Format(time.RFC3339))
+	}
+
+	if !q.Until.IsZero() {
+		conditions = append(conditions, "fetched_at <= ?")
+		args = append(args, q.Until.UTC().Format(time.RFC3339))
+	}
+
+	query := `SELECT id, project_id, project_name, tab, period_from, period_to, repo_urls, fetched_at, response FROM snapshots`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY fetched_at, id"
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying the snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []Snapshot{}
+	for rows.Next() {
+		var snapshot Snapshot
+		var repoURLs, fetchedAt, response string
+
+		err := rows.Scan(&snapshot.ID, &snapshot.ProjectID, &snapshot.ProjectName, &snapshot.Tab,
+			&snapshot.From, &snapshot.To, &repoURLs, &fetchedAt, &response)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the snapshots: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(repoURLs), &snapshot.RepoURLs); err != nil {
+			return nil, fmt.Errorf("error reading the repository URLs of snapshot %d: %w", snapshot.ID, err)
+		}
+
+		if snapshot.FetchedAt, err = time.Parse(time.RFC3339, fetchedAt); err != nil {
+			return nil, fmt.Errorf("error reading the fetch time of snapshot %d: %w", snapshot.ID, err)
+		}
+
+		snapshot.Response = json.RawMessage(response)
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading the snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,135 @@
+package store_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mdelapenya/cauldrongo/store"
+)
+
+func TestDefaultPath(t *testing.T) {
+	if got := store.DefaultPath("/home/user", ""); got != "/home/user/.local/share/cauldrongo/history.db" {
+		t.Fatalf("expected the path under ~/.local/share but got %s", got)
+	}
+
+	if got := store.DefaultPath("/home/user", "/xdg"); got != "/xdg/cauldrongo/history.db" {
+		t.Fatalf("expected the path under $XDG_DATA_HOME but got %s", got)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.db")
+
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	day := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	snapshots := []*store.Snapshot{
+		{ProjectID: 1, ProjectName: "go", Tab: "overview", From: "2024-01-01", To: "2024-02-01", RepoURLs: []string{"https://github.com/golang/go"}, FetchedAt: day, Response: []byte(`{"commits_overview":10}`)},
+		{ProjectID: 1, ProjectName: "go", Tab: "activity-overview", From: "2024-01-01", To: "2024-02-01", FetchedAt: day, Response: []byte(`{}`)},
+		{ProjectID: 2, ProjectName: "java", Tab: "overview", From: "2024-01-01", To: "2024-02-01", FetchedAt: day.AddDate(0, 0, 1), Response: []byte(`{"commits_overview":20}`)},
+	}
+
+	if err := s.Save(snapshots); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, snapshot := range snapshots {
+		if snapshot.ID != int64(i+1) {
+			t.Fatalf("expected ID %d but got %d", i+1, snapshot.ID)
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		query    store.Query
+		expected []int64
+	}{
+		{name: "all", query: store.Query{}, expected: []int64{1, 2, 3}},
+		{name: "project", query: store.Query{ProjectIDs: []int{2}}, expected: []int64{3}},
+		{name: "tab", query: store.Query{Tabs: []string{"overview"}}, expected: []int64{1, 3}},
+		{name: "since", query: store.Query{Since: day.Add(time.Hour)}, expected: []int64{3}},
+		{name: "until", query: store.Query{Until: day}, expected: []int64{1, 2}},
+		{name: "no-match", query: store.Query{ProjectIDs: []int{1}, Since: day.Add(time.Hour)}, expected: []int64{}},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(tt *testing.T) {
+			got, err := s.Snapshots(testCase.query)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			if len(got) != len(testCase.expected) {
+				tt.Fatalf("expected %d snapshots but got %d", len(testCase.expected), len(got))
+			}
+
+			for i, snapshot := range got {
+				if snapshot.ID != testCase.expected[i] {
+					tt.Fatalf("expected snapshot %d but got %d", testCase.expected[i], snapshot.ID)
+				}
+			}
+		})
+	}
+
+	t.Run("round-trip", func(tt *testing.T) {
+		got, err := s.Snapshots(store.Query{ProjectIDs: []int{1}, Tabs: []string{"overview"}})
+		if err != nil {
+			tt.Fatal(err)
+		}
+
+		snapshot := got[0]
+		if snapshot.ProjectName != "go" || snapshot.From != "2024-01-01" || snapshot.To != "2024-02-01" {
+			tt.Fatalf("unexpected snapshot %+v", snapshot)
+		}
+
+		if len(snapshot.RepoURLs) != 1 || snapshot.RepoURLs[0] != "https://github.com/golang/go" {
+			tt.Fatalf("expected the repository URLs but got %v", snapshot.RepoURLs)
+		}
+
+		if !snapshot.FetchedAt.Equal(day) {
+			tt.Fatalf("expected fetch time %s but got %s", day, snapshot.FetchedAt)
+		}
+
+		if string(snapshot.Response) != `{"commits_overview":10}` {
+			tt.Fatalf("expected the raw response but got %s", snapshot.Response)
+		}
+	})
+}
+
+func TestOpenExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	for i := 0; i < 2; i++ {
+		s, err := store.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.Save([]*store.Snapshot{{ProjectID: 1, ProjectName: "go", Tab: "overview", FetchedAt: time.Now(), Response: []byte(`{}`)}}); err != nil {
+			t.Fatal(err)
+		}
+
+		s.Close()
+	}
+
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	got, err := s.Snapshots(store.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected the snapshots of both runs but got %d", len(got))
+	}
+}